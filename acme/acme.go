@@ -0,0 +1,194 @@
+// Package acme implements solver of ACME DNS-01 challenge for domains
+// hosted by 1Cloud's DNS hosting
+package acme
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/reinventer/dns1cloud"
+)
+
+const (
+	challengeLabel = "_acme-challenge"
+
+	defaultTTL                = 60
+	defaultPropagationTimeout = 2 * time.Minute
+	defaultPollingInterval    = 5 * time.Second
+)
+
+// Client is a part of dns1cloud.DNS1Cloud used by the solver
+type Client interface {
+	FindZoneForFQDN(ctx context.Context, fqdn string) (dns1cloud.Domain, error)
+	AddRecord(ctx context.Context, domainID uint64, record dns1cloud.Record) (dns1cloud.Record, error)
+	DeleteRecord(ctx context.Context, domainID uint64, recordID uint64) error
+}
+
+// Resolver looks up TXT records, it is implemented by net.Resolver
+type Resolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// Solver creates and deletes TXT records for DNS-01 challenge
+type Solver struct {
+	client Client
+
+	ttl                uint32
+	resolver           Resolver
+	propagationTimeout time.Duration
+	pollingInterval    time.Duration
+
+	mu      sync.Mutex
+	records map[string]challengeRecord
+}
+
+type challengeRecord struct {
+	domainID uint64
+	recordID uint64
+}
+
+// OptFunc is type for option function
+type OptFunc func(*Solver)
+
+// WithTTL is option function for setting TTL of challenge records
+func WithTTL(ttl uint32) OptFunc {
+	return func(s *Solver) {
+		s.ttl = ttl
+	}
+}
+
+// WithPropagationWait is option function making Present wait until
+// the challenge record is visible through resolver
+func WithPropagationWait(resolver Resolver, timeout, interval time.Duration) OptFunc {
+	return func(s *Solver) {
+		s.resolver = resolver
+		s.propagationTimeout = timeout
+		s.pollingInterval = interval
+	}
+}
+
+// New creates and returns new Solver
+func New(client Client, opts ...OptFunc) *Solver {
+	s := &Solver{
+		client:  client,
+		ttl:     defaultTTL,
+		records: make(map[string]challengeRecord),
+	}
+
+	for _, f := range opts {
+		f(s)
+	}
+
+	if s.propagationTimeout <= 0 {
+		s.propagationTimeout = defaultPropagationTimeout
+	}
+	if s.pollingInterval <= 0 {
+		s.pollingInterval = defaultPollingInterval
+	}
+
+	return s
+}
+
+// ChallengeRecord returns fully qualified name and value of TXT record for the challenge
+func ChallengeRecord(domain, keyAuth string) (string, string) {
+	sum := sha256.Sum256([]byte(keyAuth))
+	fqdn := challengeLabel + "." + strings.TrimSuffix(domain, ".") + "."
+	return fqdn, base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// Present creates TXT record for the challenge
+func (s *Solver) Present(domain, token, keyAuth string) error {
+	return s.PresentContext(context.Background(), domain, token, keyAuth)
+}
+
+// PresentContext creates TXT record for the challenge in the domain with
+// the longest name matching the challenge
+func (s *Solver) PresentContext(ctx context.Context, domain, token, keyAuth string) error {
+	fqdn, value := ChallengeRecord(domain, keyAuth)
+
+	zone, err := s.client.FindZoneForFQDN(ctx, fqdn)
+	if err != nil {
+		return errors.Wrapf(err, "could not find domain for %s", fqdn)
+	}
+
+	record, err := s.client.AddRecord(ctx, zone.ID, dns1cloud.Record{
+		TypeRecord: dns1cloud.RecordTypeTXT,
+		HostName:   dns1cloud.RelativeName(fqdn, zone.Name),
+		Text:       value,
+		TTL:        s.ttl,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "could not add TXT record %s", fqdn)
+	}
+
+	s.mu.Lock()
+	s.records[fqdn+" "+value] = challengeRecord{domainID: zone.ID, recordID: record.ID}
+	s.mu.Unlock()
+
+	if s.resolver != nil {
+		return s.WaitForPropagation(ctx, fqdn, value)
+	}
+	return nil
+}
+
+// CleanUp deletes TXT record created by Present
+func (s *Solver) CleanUp(domain, token, keyAuth string) error {
+	return s.CleanUpContext(context.Background(), domain, token, keyAuth)
+}
+
+// CleanUpContext deletes TXT record created by Present
+func (s *Solver) CleanUpContext(ctx context.Context, domain, token, keyAuth string) error {
+	fqdn, value := ChallengeRecord(domain, keyAuth)
+	key := fqdn + " " + value
+
+	s.mu.Lock()
+	rec, ok := s.records[key]
+	s.mu.Unlock()
+	if !ok {
+		return errors.Errorf("unknown challenge record %s", fqdn)
+	}
+
+	if err := s.client.DeleteRecord(ctx, rec.domainID, rec.recordID); err != nil {
+		return errors.Wrapf(err, "could not delete TXT record %s", fqdn)
+	}
+
+	s.mu.Lock()
+	delete(s.records, key)
+	s.mu.Unlock()
+	return nil
+}
+
+// WaitForPropagation polls resolver until TXT record with value is visible or timeout is expired
+func (s *Solver) WaitForPropagation(ctx context.Context, fqdn, value string) error {
+	if s.resolver == nil {
+		return errors.New("resolver is not set")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.propagationTimeout)
+	defer cancel()
+
+	t := time.NewTicker(s.pollingInterval)
+	defer t.Stop()
+
+	for {
+		values, err := s.resolver.LookupTXT(ctx, fqdn)
+		if err == nil {
+			for _, v := range values {
+				if v == value {
+					return nil
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.Wrapf(ctx.Err(), "TXT record %s is not propagated", fqdn)
+		case <-t.C:
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package acme
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/reinventer/dns1cloud"
+	"github.com/reinventer/dns1cloud/dns1cloudtest"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	_ Client = (*dns1cloud.DNS1Cloud)(nil)
+	_ Client = (*dns1cloud.CachingClient)(nil)
+)
+
+// newTestServer returns fake server with domains domain.com, sub.domain.com and other.com
+// having IDs 1, 2 and 3
+func newTestServer() *dns1cloudtest.Server {
+	srv := dns1cloudtest.NewServer("apiKey")
+	srv.AddDomain("domain.com")
+	srv.AddDomain("sub.domain.com")
+	srv.AddDomain("other.com")
+	return srv
+}
+
+// records returns records of the domain, IDs and states are omitted
+func records(srv *dns1cloudtest.Server, domainID uint64) []dns1cloud.Record {
+	d, _ := srv.Domain(domainID)
+
+	var res []dns1cloud.Record
+	for _, r := range d.LinkedRecords {
+		res = append(res, dns1cloud.Record{TypeRecord: r.TypeRecord, HostName: r.HostName, Text: r.Text, TTL: r.TTL})
+	}
+	return res
+}
+
+type fakeResolver struct {
+	mu      sync.Mutex
+	lookups int
+	visible int
+	value   string
+}
+
+func (r *fakeResolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lookups++
+	if r.lookups < r.visible {
+		return nil, errors.New("no such host")
+	}
+	return []string{"other", r.value}, nil
+}
+
+func TestChallengeRecord(t *testing.T) {
+	fqdn, value := ChallengeRecord("www.domain.com", "token.thumbprint")
+	assert.Equal(t, "_acme-challenge.www.domain.com.", fqdn)
+	assert.Equal(t, "61rBZ_4knHblO0MNoxFsXZ_eTFUHum0B6IVRbhvUn5I", value)
+}
+
+func TestSolver(t *testing.T) {
+	testCases := []struct {
+		name        string
+		domain      string
+		expDomainID uint64
+		expHostName string
+	}{
+		{name: "apex", domain: "domain.com", expDomainID: 1, expHostName: "_acme-challenge"},
+		{name: "subdomain", domain: "www.domain.com", expDomainID: 1, expHostName: "_acme-challenge.www"},
+		{name: "longest suffix", domain: "WWW.Sub.Domain.com.", expDomainID: 2, expHostName: "_acme-challenge.www"},
+		{name: "domain itself", domain: "sub.domain.com", expDomainID: 2, expHostName: "_acme-challenge"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := newTestServer()
+			defer srv.Close()
+			s := New(srv.Client(), WithTTL(300))
+
+			assert.NoError(t, s.Present(tc.domain, "token", "token.thumbprint"))
+			assert.Equal(t, []dns1cloud.Record{{
+				TypeRecord: dns1cloud.RecordTypeTXT,
+				HostName:   tc.expHostName,
+				Text:       "61rBZ_4knHblO0MNoxFsXZ_eTFUHum0B6IVRbhvUn5I",
+				TTL:        300,
+			}}, records(srv, tc.expDomainID))
+
+			assert.NoError(t, s.CleanUp(tc.domain, "token", "token.thumbprint"))
+			assert.Empty(t, records(srv, tc.expDomainID))
+
+			assert.EqualError(t, s.CleanUp(tc.domain, "token", "token.thumbprint"),
+				"unknown challenge record _acme-challenge."+trimDot(tc.domain)+".")
+		})
+	}
+}
+
+func trimDot(s string) string {
+	if len(s) > 0 && s[len(s)-1] == '.' {
+		return s[:len(s)-1]
+	}
+	return s
+}
+
+func TestSolver_ConcurrentChallenges(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	s := New(srv.Client())
+
+	assert.NoError(t, s.Present("domain.com", "token1", "keyAuth1"))
+	assert.NoError(t, s.Present("domain.com", "token2", "keyAuth2"))
+
+	// the second record is deleted exactly
+	_, value := ChallengeRecord("domain.com", "keyAuth1")
+	assert.NoError(t, s.CleanUp("domain.com", "token2", "keyAuth2"))
+	assert.Equal(t, []dns1cloud.Record{
+		{TypeRecord: dns1cloud.RecordTypeTXT, HostName: "_acme-challenge", Text: value, TTL: defaultTTL},
+	}, records(srv, 1))
+}
+
+func TestSolver_DomainNotFound(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	s := New(srv.Client())
+
+	assert.EqualError(t, s.Present("notdomain.com", "token", "keyAuth"),
+		`could not find domain for _acme-challenge.notdomain.com.: zone "_acme-challenge.notdomain.com" is not found`)
+
+	srv.InjectFault(dns1cloudtest.Fault{Method: http.MethodGet, Status: http.StatusBadRequest})
+	err := s.Present("domain.com", "token", "keyAuth")
+	assert.EqualError(t, err, "could not find domain for _acme-challenge.domain.com.: could not find zone: "+
+		"could not send command list: bad response, status: 400, body: '{\"Message\":\"Bad Request\"}\n'")
+	assert.True(t, dns1cloud.IsBadRequest(err))
+}
+
+func TestSolver_PropagationWait(t *testing.T) {
+	_, value := ChallengeRecord("domain.com", "keyAuth")
+
+	srv := newTestServer()
+	defer srv.Close()
+
+	r := &fakeResolver{visible: 3, value: value}
+	s := New(srv.Client(), WithPropagationWait(r, time.Second, time.Millisecond))
+
+	assert.NoError(t, s.Present("domain.com", "token", "keyAuth"))
+	assert.Equal(t, 3, r.lookups)
+
+	r = &fakeResolver{value: "stale"}
+	s = New(srv.Client(), WithPropagationWait(r, 20*time.Millisecond, time.Millisecond))
+
+	err := s.Present("domain.com", "token", "keyAuth")
+	assert.EqualError(t, err, "TXT record _acme-challenge.domain.com. is not propagated: context deadline exceeded")
+}
@@ -0,0 +1,32 @@
+package dns1cloud
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+// addDomainParams parameters for request for creating domain
+type addDomainParams struct {
+	Name string `json:"Name"`
+}
+
+// AddDomain adds new domain
+func (c *DNS1Cloud) AddDomain(ctx context.Context, name string) (domain Domain, err error) {
+	ctx, sp := c.startSpan(ctx, "add_domain")
+	defer func() { sp.end(err) }()
+
+	cmd := command{
+		name:     "add_domain",
+		method:   http.MethodPost,
+		endpoint: "dns",
+		params:   &addDomainParams{Name: name},
+	}
+
+	if err = c.send(ctx, cmd, &domain); err != nil {
+		return domain, errors.Wrap(err, "could not send command add_domain")
+	}
+	sp.setAttributes(Attribute{Key: attrDomainID, Value: domain.ID})
+	return domain, nil
+}
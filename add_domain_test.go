@@ -0,0 +1,79 @@
+package dns1cloud
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDNS1Cloud_AddDomain(t *testing.T) {
+	testCases := []struct {
+		name           string
+		responseStatus int
+		responseJSON   string
+		expDomain      Domain
+		expErrString   string
+	}{
+		{
+			name:           "success",
+			responseStatus: http.StatusOK,
+			responseJSON:   `{"ID":123,"Name":"domain.com","TechName":"domain_com","State":"New","DateCreate":"2019-02-02T21:17:11.64","IsDelegate":false,"LinkedRecords":[]}`,
+			expDomain: Domain{
+				ID:            123,
+				Name:          "domain.com",
+				TechName:      "domain_com",
+				State:         StateNew,
+				DateCreate:    DateTime{Time: time.Date(2019, 2, 2, 21, 17, 11, 640000000, time.UTC)},
+				IsDelegate:    false,
+				LinkedRecords: []Record{},
+			},
+		},
+		{
+			name:           "invalid json",
+			responseStatus: http.StatusOK,
+			responseJSON:   "invalid json",
+			expErrString:   "could not send command add_domain: could not unmarshal response: invalid character 'i' looking for beginning of value",
+		},
+		{
+			name:           "bad response",
+			responseStatus: http.StatusBadRequest,
+			responseJSON:   `{"Message": "domain already exists"}`,
+			expErrString:   `could not send command add_domain: bad response, status: 400, body: '{"Message": "domain already exists"}'`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/dns", r.URL.Path)
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "Bearer apiKey", r.Header.Get("Authorization"))
+
+				reqBody, err := ioutil.ReadAll(r.Body)
+				defer r.Body.Close()
+
+				assert.NoError(t, err)
+				assert.JSONEq(t, `{"Name": "domain.com"}`, string(reqBody))
+				w.WriteHeader(tc.responseStatus)
+				w.Write([]byte(tc.responseJSON))
+			}))
+			defer s.Close()
+
+			c := New("apiKey", WithApiHost(s.URL))
+
+			domain, err := c.AddDomain(context.Background(), "domain.com")
+			if len(tc.expErrString) > 0 {
+				assert.EqualError(t, err, tc.expErrString)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tc.expDomain, domain)
+		})
+	}
+}
@@ -13,17 +13,34 @@ var validTTLs = [...]string{
 	"1", "5", "30", "60", "300", "600", "900", "1800", "3600", "7200", "21160", "43200", "86400",
 }
 
+// IsValidTTL reports whether TTL is accepted by API
+func IsValidTTL(ttl uint32) bool {
+	str := strconv.FormatUint(uint64(ttl), 10)
+	for _, t := range validTTLs {
+		if t == str {
+			return true
+		}
+	}
+	return false
+}
+
 // AddRecord adds record to domain
 func (c *DNS1Cloud) AddRecord(
 	ctx context.Context,
 	domainID uint64,
 	record Record,
-) (Record, error) {
-	var (
-		res Record
-		cmd command
-		err error
+) (res Record, err error) {
+	ctx, sp := c.startSpan(ctx, "add_record",
+		Attribute{Key: attrDomainID, Value: domainID},
+		Attribute{Key: attrRecordType, Value: record.TypeRecord.String()},
 	)
+	defer func() { sp.end(err) }()
+
+	if err = record.Validate(); err != nil {
+		return res, err
+	}
+
+	var cmd command
 
 	switch record.TypeRecord {
 	case RecordTypeA:
@@ -40,15 +57,23 @@ func (c *DNS1Cloud) AddRecord(
 		cmd, err = makeAddRecordSRVCommand(domainID, record)
 	case RecordTypeTXT:
 		cmd, err = makeAddRecordTXTCommand(domainID, record)
+	case RecordTypeCAA:
+		cmd, err = makeAddRecordCAACommand(domainID, record)
+	case RecordTypePTR:
+		cmd, err = makeAddRecordPTRCommand(domainID, record)
 	default:
-		err = errors.Errorf("unknown record type: %d", record.TypeRecord)
+		err = &UnknownRecordTypeError{Type: record.TypeRecord, Raw: record.RawTypeRecord}
 	}
 	if err != nil {
 		return res, err
 	}
 
-	err = c.send(ctx, cmd, &res)
-	return res, err
+	cmd.name = "add_record"
+	if err = c.send(ctx, cmd, &res); err != nil {
+		return res, err
+	}
+	sp.setAttributes(Attribute{Key: attrRecordID, Value: res.ID})
+	return res, nil
 }
 
 // addRecordAParams parameters for request for creating A and AAAA records
@@ -104,6 +129,24 @@ type addRecordTXTParams struct {
 	TTL      string `json:"TTL,omitempty"`
 }
 
+// addRecordCAAParams parameters for request for creating CAA records
+type addRecordCAAParams struct {
+	DomainID string `json:"DomainId"`
+	Name     string `json:"Name"`
+	Flag     string `json:"Flag"`
+	Tag      string `json:"Tag"`
+	Value    string `json:"Value"`
+	TTL      string `json:"TTL,omitempty"`
+}
+
+// addRecordPTRParams parameters for request for creating PTR records
+type addRecordPTRParams struct {
+	DomainID string `json:"DomainId"`
+	Name     string `json:"Name"`
+	Target   string `json:"Target"`
+	TTL      string `json:"TTL,omitempty"`
+}
+
 func makeAddRecordACommand(domainID uint64, record Record) (command, error) {
 	ttl, err := getTTL(record.TTL)
 	if err != nil {
@@ -258,3 +301,45 @@ func makeAddRecordTXTCommand(domainID uint64, record Record) (command, error) {
 		params:   &params,
 	}, nil
 }
+
+func makeAddRecordCAACommand(domainID uint64, record Record) (command, error) {
+	ttl, err := getTTL(record.TTL)
+	if err != nil {
+		return command{}, err
+	}
+
+	params := addRecordCAAParams{
+		DomainID: strconv.FormatUint(domainID, 10),
+		Name:     record.HostName,
+		Flag:     record.Flag,
+		Tag:      record.Tag,
+		Value:    record.Value,
+		TTL:      ttl,
+	}
+
+	return command{
+		method:   http.MethodPost,
+		endpoint: "dns/recordcaa",
+		params:   &params,
+	}, nil
+}
+
+func makeAddRecordPTRCommand(domainID uint64, record Record) (command, error) {
+	ttl, err := getTTL(record.TTL)
+	if err != nil {
+		return command{}, err
+	}
+
+	params := addRecordPTRParams{
+		DomainID: strconv.FormatUint(domainID, 10),
+		Name:     record.HostName,
+		Target:   record.Target,
+		TTL:      ttl,
+	}
+
+	return command{
+		method:   http.MethodPost,
+		endpoint: "dns/recordptr",
+		params:   &params,
+	}, nil
+}
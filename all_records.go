@@ -0,0 +1,214 @@
+package dns1cloud
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// AllRecordsOptFunc is type for option function of AllRecords
+type AllRecordsOptFunc func(*RecordIterator)
+
+// WithRefresh is option function which makes AllRecords fetch every domain by GetDomain
+// instead of using records returned by List. At most concurrency domains are fetched at once
+func WithRefresh(concurrency int) AllRecordsOptFunc {
+	return func(it *RecordIterator) {
+		if concurrency < 1 {
+			concurrency = 1
+		}
+		it.concurrency = concurrency
+	}
+}
+
+// RecordIterator iterates over records of all domains, it is returned by AllRecords.
+// Iterator is not safe for concurrent use
+type RecordIterator struct {
+	client      *DNS1Cloud
+	ctx         context.Context
+	cancel      context.CancelFunc
+	concurrency int
+
+	started bool
+	done    bool
+	domains []Domain
+	results []chan domainResult
+	sem     chan struct{}
+	pos     int
+
+	domain  Domain
+	record  Record
+	records []Record
+	idx     int
+
+	domainErrs []*DomainError
+	err        error
+}
+
+type domainResult struct {
+	domain Domain
+	err    error
+}
+
+// AllRecords returns iterator over records of all domains. Domains are walked in order of List.
+// Errors of single domains do not stop the walk, they are returned by DomainErrors.
+//
+//	it := c.AllRecords(ctx)
+//	defer it.Close()
+//	for it.Next() {
+//		fmt.Println(it.Domain().Name, it.Record().TypeRecord)
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+func (c *DNS1Cloud) AllRecords(ctx context.Context, opts ...AllRecordsOptFunc) *RecordIterator {
+	ctx, cancel := context.WithCancel(ctx)
+	it := &RecordIterator{
+		client: c,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+
+	for _, f := range opts {
+		f(it)
+	}
+
+	return it
+}
+
+// Next advances iterator to the next record, it returns false when records are
+// over or the walk is stopped by an error
+func (it *RecordIterator) Next() bool {
+	for {
+		if it.done {
+			return false
+		}
+
+		if it.idx < len(it.records) {
+			it.record = it.records[it.idx]
+			it.idx++
+			return true
+		}
+
+		if err := it.ctx.Err(); err != nil {
+			it.stop(err)
+			return false
+		}
+
+		if !it.started {
+			if err := it.start(); err != nil {
+				it.stop(err)
+				return false
+			}
+		}
+
+		if it.pos >= len(it.domains) {
+			it.stop(nil)
+			return false
+		}
+
+		domain, err := it.fetch(it.pos)
+		it.pos++
+		if err != nil {
+			if ctxErr := it.ctx.Err(); ctxErr != nil {
+				it.stop(ctxErr)
+				return false
+			}
+			it.domainErrs = append(it.domainErrs, &DomainError{Domain: domain, Err: err})
+			continue
+		}
+
+		it.domain, it.records, it.idx = domain, domain.LinkedRecords, 0
+	}
+}
+
+// Domain returns domain of the current record
+func (it *RecordIterator) Domain() Domain {
+	return it.domain
+}
+
+// Record returns the current record
+func (it *RecordIterator) Record() Record {
+	return it.record
+}
+
+// Err returns error which stopped the walk, e.g. failed List or cancelled context
+func (it *RecordIterator) Err() error {
+	return it.err
+}
+
+// DomainErrors returns errors of domains which were skipped
+func (it *RecordIterator) DomainErrors() []*DomainError {
+	return it.domainErrs
+}
+
+// Close stops the walk and releases its resources, it is safe to call Close several times
+func (it *RecordIterator) Close() {
+	it.stop(nil)
+}
+
+func (it *RecordIterator) stop(err error) {
+	if it.done {
+		return
+	}
+	it.done = true
+	it.err = err
+	it.records = nil
+	it.cancel()
+}
+
+func (it *RecordIterator) start() error {
+	it.started = true
+
+	domains, err := it.client.List(it.ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not list domains")
+	}
+	it.domains = domains
+
+	if it.concurrency == 0 {
+		return nil
+	}
+
+	it.results = make([]chan domainResult, len(domains))
+	for i := range it.results {
+		it.results[i] = make(chan domainResult, 1)
+	}
+	it.sem = make(chan struct{}, it.concurrency)
+
+	go it.prefetch()
+	return nil
+}
+
+// prefetch fetches domains ahead of the consumer, the slot of semaphore is released
+// when the consumer takes the result, so at most concurrency domains are kept in memory
+func (it *RecordIterator) prefetch() {
+	for i, d := range it.domains {
+		select {
+		case it.sem <- struct{}{}:
+		case <-it.ctx.Done():
+			return
+		}
+
+		go func(i int, d Domain) {
+			domain, err := it.client.GetDomain(it.ctx, d.ID)
+			if err != nil {
+				domain = Domain{ID: d.ID, Name: d.Name}
+			}
+			it.results[i] <- domainResult{domain: domain, err: err}
+		}(i, d)
+	}
+}
+
+func (it *RecordIterator) fetch(i int) (Domain, error) {
+	if it.concurrency == 0 {
+		return it.domains[i], nil
+	}
+
+	select {
+	case res := <-it.results[i]:
+		<-it.sem
+		return res.domain, res.err
+	case <-it.ctx.Done():
+		return Domain{ID: it.domains[i].ID, Name: it.domains[i].Name}, it.ctx.Err()
+	}
+}
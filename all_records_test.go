@@ -0,0 +1,155 @@
+package dns1cloud
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const allRecordsListJSON = `[
+	{"ID":1,"Name":"one.com","LinkedRecords":[{"ID":10,"TypeRecord":"A","IP":"1.1.1.1","HostName":"@"}]},
+	{"ID":2,"Name":"two.com","LinkedRecords":[]},
+	{"ID":3,"Name":"three.com","LinkedRecords":[{"ID":30,"TypeRecord":"TXT","Text":"a","HostName":"@"},{"ID":31,"TypeRecord":"TXT","Text":"b","HostName":"@"}]}
+]`
+
+var allRecordsDomainJSON = map[string]string{
+	"/dns/1": `{"ID":1,"Name":"one.com","LinkedRecords":[{"ID":10,"TypeRecord":"A","IP":"1.1.1.2","HostName":"@"}]}`,
+	"/dns/3": `{"ID":3,"Name":"three.com","LinkedRecords":[{"ID":30,"TypeRecord":"TXT","Text":"a","HostName":"@"}]}`,
+}
+
+type walkedRecord struct {
+	domainID uint64
+	recordID uint64
+}
+
+func walk(it *RecordIterator) []walkedRecord {
+	var res []walkedRecord
+	for it.Next() {
+		res = append(res, walkedRecord{domainID: it.Domain().ID, recordID: it.Record().ID})
+	}
+	return res
+}
+
+func TestDNS1Cloud_AllRecords(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		inFlight int
+		maxIn    int
+	)
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/dns" {
+			w.Write([]byte(allRecordsListJSON))
+			return
+		}
+
+		mu.Lock()
+		inFlight++
+		if inFlight > maxIn {
+			maxIn = inFlight
+		}
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}()
+
+		body, ok := allRecordsDomainJSON[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	defer s.Close()
+
+	c := New("apiKey", WithApiHost(s.URL))
+
+	t.Run("records of list", func(t *testing.T) {
+		it := c.AllRecords(context.Background())
+		defer it.Close()
+
+		assert.Equal(t, []walkedRecord{{1, 10}, {3, 30}, {3, 31}}, walk(it))
+		assert.NoError(t, it.Err())
+		assert.Empty(t, it.DomainErrors())
+	})
+
+	t.Run("refresh", func(t *testing.T) {
+		it := c.AllRecords(context.Background(), WithRefresh(2))
+		defer it.Close()
+
+		var ips []string
+		for it.Next() {
+			if it.Record().TypeRecord == RecordTypeA {
+				ips = append(ips, it.Record().IP)
+			}
+			assert.NotEqual(t, uint64(2), it.Domain().ID)
+		}
+		assert.NoError(t, it.Err())
+		assert.Equal(t, []string{"1.1.1.2"}, ips)
+
+		require.Len(t, it.DomainErrors(), 1)
+		err := it.DomainErrors()[0]
+		assert.Equal(t, uint64(2), err.Domain.ID)
+		assert.Equal(t, "two.com", err.Domain.Name)
+		assert.True(t, IsNotFound(err))
+
+		mu.Lock()
+		assert.True(t, maxIn <= 2, "max concurrent requests: %d", maxIn)
+		mu.Unlock()
+	})
+
+	t.Run("stop early", func(t *testing.T) {
+		it := c.AllRecords(context.Background(), WithRefresh(1))
+		require.True(t, it.Next())
+		it.Close()
+		assert.False(t, it.Next())
+		assert.NoError(t, it.Err())
+	})
+}
+
+func TestDNS1Cloud_AllRecords_cancel(t *testing.T) {
+	var requests int32
+	ctx, cancel := context.WithCancel(context.Background())
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.URL.Path == "/dns" {
+			w.Write([]byte(allRecordsListJSON))
+			return
+		}
+		// the context is cancelled while domain is fetched
+		cancel()
+		<-r.Context().Done()
+	}))
+	defer s.Close()
+
+	c := New("apiKey", WithApiHost(s.URL))
+	it := c.AllRecords(ctx, WithRefresh(1))
+	defer it.Close()
+
+	assert.Empty(t, walk(it))
+	assert.Equal(t, context.Canceled, it.Err())
+	assert.Empty(t, it.DomainErrors())
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+}
+
+func TestDNS1Cloud_AllRecords_listError(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer s.Close()
+
+	c := New("apiKey", WithApiHost(s.URL))
+	it := c.AllRecords(context.Background())
+	defer it.Close()
+
+	assert.Empty(t, walk(it))
+	assert.True(t, IsUnauthorized(it.Err()))
+	assert.EqualError(t, it.Err(), "could not list domains: could not send command list: bad response, status: 401, body: ''")
+}
@@ -0,0 +1,165 @@
+package dns1cloud
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultBatchWorkers is the number of concurrent requests of batch operations by default
+const DefaultBatchWorkers = 4
+
+// ErrSkipped is set as error of batch items which were not started because of the previous error
+var ErrSkipped = errors.New("skipped after previous error")
+
+// BatchResult is result of one item of batch operation
+type BatchResult struct {
+	// Record is the record returned by API, only ID is set for deleted records
+	Record Record
+	// Err is error of the item
+	Err error
+}
+
+type batchOptions struct {
+	workers     int
+	stopOnError bool
+}
+
+// BatchOptFunc is type for option function of batch operations
+type BatchOptFunc func(*batchOptions)
+
+// WithWorkers is option function for setting the number of concurrent requests.
+// Requests still pass through the rate limiter of the client
+func WithWorkers(n int) BatchOptFunc {
+	return func(o *batchOptions) {
+		if n < 1 {
+			n = 1
+		}
+		o.workers = n
+	}
+}
+
+// WithStopOnError is option function which makes batch operation stop starting new items
+// after the first failure, items in flight are finished. By default all items are tried
+func WithStopOnError() BatchOptFunc {
+	return func(o *batchOptions) {
+		o.stopOnError = true
+	}
+}
+
+// AddRecords adds records to domain. Results are in order of records
+func (c *DNS1Cloud) AddRecords(ctx context.Context, domainID uint64, records []Record, opts ...BatchOptFunc) ([]BatchResult, error) {
+	return addRecords(ctx, c, domainID, records, opts)
+}
+
+// UpdateRecords updates records of domain. Results are in order of records
+func (c *DNS1Cloud) UpdateRecords(ctx context.Context, domainID uint64, records []Record, opts ...BatchOptFunc) ([]BatchResult, error) {
+	return updateRecords(ctx, c, domainID, records, opts)
+}
+
+// DeleteRecords deletes records from domain. Results are in order of recordIDs
+func (c *DNS1Cloud) DeleteRecords(ctx context.Context, domainID uint64, recordIDs []uint64, opts ...BatchOptFunc) ([]BatchResult, error) {
+	return deleteRecords(ctx, c, domainID, recordIDs, opts)
+}
+
+func addRecords(ctx context.Context, c recordClient, domainID uint64, records []Record, opts []BatchOptFunc) ([]BatchResult, error) {
+	return runBatch(ctx, len(records), opts, func(ctx context.Context, i int) (Record, error) {
+		return c.AddRecord(ctx, domainID, records[i])
+	})
+}
+
+func updateRecords(ctx context.Context, c recordClient, domainID uint64, records []Record, opts []BatchOptFunc) ([]BatchResult, error) {
+	return runBatch(ctx, len(records), opts, func(ctx context.Context, i int) (Record, error) {
+		return c.UpdateRecord(ctx, domainID, records[i])
+	})
+}
+
+func deleteRecords(ctx context.Context, c recordClient, domainID uint64, recordIDs []uint64, opts []BatchOptFunc) ([]BatchResult, error) {
+	return runBatch(ctx, len(recordIDs), opts, func(ctx context.Context, i int) (Record, error) {
+		return Record{ID: recordIDs[i]}, c.DeleteRecord(ctx, domainID, recordIDs[i])
+	})
+}
+
+// runBatch calls op for items 0..n-1 by workers. Error is returned if any item failed:
+// the first error in stop-on-error mode or the number of failed items otherwise
+func runBatch(
+	ctx context.Context,
+	n int,
+	opts []BatchOptFunc,
+	op func(ctx context.Context, i int) (Record, error),
+) ([]BatchResult, error) {
+	o := batchOptions{workers: DefaultBatchWorkers}
+	for _, f := range opts {
+		f(&o)
+	}
+
+	var (
+		results  = make([]BatchResult, n)
+		mu       sync.Mutex
+		next     int
+		firstErr = -1
+		wg       sync.WaitGroup
+	)
+
+	// take returns index of the next item or false if there is nothing to start
+	take := func() (int, bool) {
+		mu.Lock()
+		defer mu.Unlock()
+		if next >= n || firstErr >= 0 || ctx.Err() != nil {
+			return 0, false
+		}
+		next++
+		return next - 1, true
+	}
+
+	workers := o.workers
+	if workers > n {
+		workers = n
+	}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				i, ok := take()
+				if !ok {
+					return
+				}
+
+				rec, err := op(ctx, i)
+				results[i] = BatchResult{Record: rec, Err: err}
+				if err != nil && o.stopOnError {
+					mu.Lock()
+					if firstErr < 0 {
+						firstErr = i
+					}
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	failed := 0
+	for i := range results {
+		if i >= next {
+			if firstErr >= 0 {
+				results[i].Err = ErrSkipped
+			} else {
+				results[i].Err = ctx.Err()
+			}
+		}
+		if results[i].Err != nil {
+			failed++
+		}
+	}
+
+	if firstErr >= 0 {
+		return results, errors.Wrapf(results[firstErr].Err, "operation %d of %d failed", firstErr+1, n)
+	}
+	if failed > 0 {
+		return results, errors.Errorf("%d of %d operations failed", failed, n)
+	}
+	return results, nil
+}
@@ -0,0 +1,146 @@
+package dns1cloud_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/reinventer/dns1cloud"
+	"github.com/reinventer/dns1cloud/dns1cloudtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// inFlightClient returns HTTP client tracking the maximum number of requests in flight
+func inFlightClient(maxInFlight *int) *http.Client {
+	var (
+		mu       sync.Mutex
+		inFlight int
+	)
+	return &http.Client{
+		Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			mu.Lock()
+			inFlight++
+			if inFlight > *maxInFlight {
+				*maxInFlight = inFlight
+			}
+			mu.Unlock()
+			defer func() {
+				mu.Lock()
+				inFlight--
+				mu.Unlock()
+			}()
+			return http.DefaultTransport.RoundTrip(r)
+		}),
+	}
+}
+
+func batchRecords(n int) []dns1cloud.Record {
+	records := make([]dns1cloud.Record, n)
+	for i := range records {
+		records[i] = dns1cloud.Record{
+			ID:         uint64(i + 1),
+			TypeRecord: dns1cloud.RecordTypeA,
+			HostName:   "@",
+			IP:         fmt.Sprintf("10.0.0.%d", i+1),
+		}
+	}
+	return records
+}
+
+func TestDNS1Cloud_AddRecords(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	srv.InjectFault(dns1cloudtest.Fault{Method: http.MethodPost, Status: http.StatusBadRequest, Times: 1})
+	srv.InjectFault(dns1cloudtest.Fault{Latency: time.Millisecond})
+
+	maxInFlight := 0
+	c := srv.Client(dns1cloud.WithHTTPClient(inFlightClient(&maxInFlight)))
+
+	results, err := c.AddRecords(context.Background(), testDomainID, batchRecords(20), dns1cloud.WithWorkers(3))
+	assert.EqualError(t, err, "1 of 20 operations failed")
+	require.Len(t, results, 20)
+
+	failed := 0
+	for i, res := range results {
+		if res.Err != nil {
+			assert.True(t, dns1cloud.IsBadRequest(res.Err))
+			failed++
+			continue
+		}
+		assert.Equal(t, fmt.Sprintf("10.0.0.%d", i+1), res.Record.IP)
+	}
+	assert.Equal(t, 1, failed)
+	assert.True(t, maxInFlight <= 3, "max requests in flight: %d", maxInFlight)
+
+	d, _ := srv.Domain(testDomainID)
+	assert.Len(t, d.LinkedRecords, 19)
+
+	results, err = c.AddRecords(context.Background(), testDomainID, batchRecords(5))
+	assert.NoError(t, err)
+	assert.Len(t, results, 5)
+}
+
+func TestDNS1Cloud_UpdateRecords_stopOnError(t *testing.T) {
+	srv := newTestServer(batchRecords(20)...)
+	defer srv.Close()
+	srv.InjectFault(dns1cloudtest.Fault{Method: http.MethodPut, PathPrefix: "/dns/recorda/13", Status: http.StatusBadRequest})
+
+	records := batchRecords(20)
+	for i := range records {
+		records[i].TTL = 600
+	}
+
+	results, err := srv.Client().UpdateRecords(context.Background(), testDomainID, records, dns1cloud.WithWorkers(1), dns1cloud.WithStopOnError())
+	assert.EqualError(t, err, "operation 13 of 20 failed: bad response, status: 400, body: '"+badRequestBody+"'")
+	assert.True(t, dns1cloud.IsBadRequest(err))
+	require.Len(t, results, 20)
+	for i, res := range results {
+		switch {
+		case i < 12:
+			assert.NoError(t, res.Err)
+			assert.Equal(t, uint64(i+1), res.Record.ID)
+			assert.Equal(t, uint32(600), res.Record.TTL)
+		case i == 12:
+			assert.True(t, dns1cloud.IsBadRequest(res.Err))
+		default:
+			assert.Equal(t, dns1cloud.ErrSkipped, res.Err)
+		}
+	}
+}
+
+func TestDNS1Cloud_DeleteRecords(t *testing.T) {
+	srv := newTestServer(batchRecords(20)...)
+	defer srv.Close()
+
+	ids := []uint64{11, 12, 100, 14}
+	results, err := srv.Client().DeleteRecords(context.Background(), testDomainID, ids, dns1cloud.WithWorkers(10))
+	assert.EqualError(t, err, "1 of 4 operations failed")
+	require.Len(t, results, 4)
+	for i, res := range results {
+		assert.Equal(t, ids[i], res.Record.ID)
+	}
+	assert.NoError(t, results[0].Err)
+	assert.True(t, dns1cloud.IsNotFound(results[2].Err))
+
+	d, _ := srv.Domain(testDomainID)
+	assert.Len(t, d.LinkedRecords, 17)
+}
+
+func TestDNS1Cloud_AddRecords_cancel(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results, err := srv.Client().AddRecords(ctx, testDomainID, batchRecords(3))
+	assert.EqualError(t, err, "3 of 3 operations failed")
+	for _, res := range results {
+		assert.Equal(t, context.Canceled, res.Err)
+	}
+	assert.Equal(t, 0, srv.Requests())
+}
@@ -0,0 +1,283 @@
+package dns1cloud
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultCacheTTL is how long results of List and GetDomain are cached by default
+const DefaultCacheTTL = time.Minute
+
+const listCacheKey = "list"
+
+// CachingClient wraps DNS1Cloud and caches results of List and GetDomain.
+// Concurrent identical requests are coalesced into one. Every method changing records,
+// including batch operations, change sets and sync plans, invalidates the cached domain and list,
+// AddDomain and DeleteDomain invalidate the list. Only methods changing records through
+// the caching client are exposed, so the cache can not be bypassed by accident
+type CachingClient struct {
+	client *DNS1Cloud
+
+	listTTL   time.Duration
+	domainTTL time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	gen      uint64
+	entries  map[string]cacheEntry
+	inflight map[string]*cacheCall
+}
+
+type cacheEntry struct {
+	value   interface{}
+	expires time.Time
+}
+
+// cacheCall is a request in flight which concurrent callers wait for
+type cacheCall struct {
+	done  chan struct{}
+	value interface{}
+	err   error
+}
+
+// CacheOptFunc is type for option function of CachingClient
+type CacheOptFunc func(*CachingClient)
+
+// WithListTTL is option function for setting how long result of List is cached, zero disables caching
+func WithListTTL(ttl time.Duration) CacheOptFunc {
+	return func(c *CachingClient) {
+		c.listTTL = ttl
+	}
+}
+
+// WithDomainTTL is option function for setting how long result of GetDomain is cached, zero disables caching
+func WithDomainTTL(ttl time.Duration) CacheOptFunc {
+	return func(c *CachingClient) {
+		c.domainTTL = ttl
+	}
+}
+
+// NewCachingClient creates and returns new CachingClient over the client
+func NewCachingClient(client *DNS1Cloud, opts ...CacheOptFunc) *CachingClient {
+	c := &CachingClient{
+		client:    client,
+		listTTL:   DefaultCacheTTL,
+		domainTTL: DefaultCacheTTL,
+		now:       time.Now,
+		entries:   make(map[string]cacheEntry),
+		inflight:  make(map[string]*cacheCall),
+	}
+
+	for _, f := range opts {
+		f(c)
+	}
+
+	return c
+}
+
+// List returns list of domains from cache or from API
+func (c *CachingClient) List(ctx context.Context) ([]Domain, error) {
+	v, err := c.get(ctx, listCacheKey, c.listTTL, func(ctx context.Context) (interface{}, error) {
+		return c.client.List(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	domains := v.([]Domain)
+	res := make([]Domain, len(domains))
+	for i, d := range domains {
+		res[i] = copyDomain(d)
+	}
+	return res, nil
+}
+
+// FindZoneForFQDN returns domain with the longest name which fqdn belongs to,
+// the list of domains is taken from cache or from API
+func (c *CachingClient) FindZoneForFQDN(ctx context.Context, fqdn string) (Domain, error) {
+	domains, err := c.List(ctx)
+	if err != nil {
+		return Domain{}, errors.Wrap(err, "could not find zone")
+	}
+	return zoneForFQDN(domains, fqdn)
+}
+
+// GetDomain returns domain by id from cache or from API
+func (c *CachingClient) GetDomain(ctx context.Context, domainID uint64) (Domain, error) {
+	v, err := c.get(ctx, domainCacheKey(domainID), c.domainTTL, func(ctx context.Context) (interface{}, error) {
+		return c.client.GetDomain(ctx, domainID)
+	})
+	if err != nil {
+		return Domain{}, err
+	}
+	return copyDomain(v.(Domain)), nil
+}
+
+// AddDomain adds new domain and invalidates cached list
+func (c *CachingClient) AddDomain(ctx context.Context, name string) (Domain, error) {
+	defer c.InvalidateList()
+	return c.client.AddDomain(ctx, name)
+}
+
+// DeleteDomain deletes domain and invalidates it in cache
+func (c *CachingClient) DeleteDomain(ctx context.Context, domainID uint64) error {
+	defer c.Invalidate(domainID)
+	return c.client.DeleteDomain(ctx, domainID)
+}
+
+// AddRecord adds record to domain and invalidates the domain in cache
+func (c *CachingClient) AddRecord(ctx context.Context, domainID uint64, record Record) (Record, error) {
+	defer c.Invalidate(domainID)
+	return c.client.AddRecord(ctx, domainID, record)
+}
+
+// UpdateRecord updates record and invalidates the domain in cache
+func (c *CachingClient) UpdateRecord(ctx context.Context, domainID uint64, record Record) (Record, error) {
+	defer c.Invalidate(domainID)
+	return c.client.UpdateRecord(ctx, domainID, record)
+}
+
+// DeleteRecord deletes record from domain and invalidates the domain in cache
+func (c *CachingClient) DeleteRecord(ctx context.Context, domainID uint64, recordID uint64) error {
+	defer c.Invalidate(domainID)
+	return c.client.DeleteRecord(ctx, domainID, recordID)
+}
+
+// GetRecord returns record by id from API, records are not cached
+func (c *CachingClient) GetRecord(ctx context.Context, recordID uint64) (Record, error) {
+	return c.client.GetRecord(ctx, recordID)
+}
+
+// AddRecords adds records to domain by AddRecord of the caching client. Results are in order of records
+func (c *CachingClient) AddRecords(ctx context.Context, domainID uint64, records []Record, opts ...BatchOptFunc) ([]BatchResult, error) {
+	return addRecords(ctx, c, domainID, records, opts)
+}
+
+// UpdateRecords updates records of domain by UpdateRecord of the caching client. Results are in order of records
+func (c *CachingClient) UpdateRecords(ctx context.Context, domainID uint64, records []Record, opts ...BatchOptFunc) ([]BatchResult, error) {
+	return updateRecords(ctx, c, domainID, records, opts)
+}
+
+// DeleteRecords deletes records from domain by DeleteRecord of the caching client. Results are in order of recordIDs
+func (c *CachingClient) DeleteRecords(ctx context.Context, domainID uint64, recordIDs []uint64, opts ...BatchOptFunc) ([]BatchResult, error) {
+	return deleteRecords(ctx, c, domainID, recordIDs, opts)
+}
+
+// NewChangeSet creates and returns new empty ChangeSet of domain applied through the caching client
+func (c *CachingClient) NewChangeSet(domainID uint64) *ChangeSet {
+	s := c.client.NewChangeSet(domainID)
+	s.client = c
+	return s
+}
+
+// PlanSync returns the plan of changes like DNS1Cloud.PlanSync. The plan is made from records
+// got from API bypassing the cache and it is applied through the caching client
+func (c *CachingClient) PlanSync(ctx context.Context, domainID uint64, desired []Record) (*Plan, error) {
+	plan, err := c.client.PlanSync(ctx, domainID, desired)
+	if err != nil {
+		return nil, err
+	}
+	plan.client = c
+	return plan, nil
+}
+
+// Invalidate removes domain and list of domains, which contains records of the domain, from cache
+func (c *CachingClient) Invalidate(domainID uint64) {
+	c.invalidate(domainCacheKey(domainID), listCacheKey)
+}
+
+// InvalidateList removes list of domains from cache
+func (c *CachingClient) InvalidateList() {
+	c.invalidate(listCacheKey)
+}
+
+// InvalidateAll removes everything from cache
+func (c *CachingClient) InvalidateAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.gen++
+	c.entries = make(map[string]cacheEntry)
+	c.inflight = make(map[string]*cacheCall)
+}
+
+func (c *CachingClient) invalidate(keys ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// requests in flight could return the state before the change, so they are not cached
+	c.gen++
+	for _, k := range keys {
+		delete(c.entries, k)
+		delete(c.inflight, k)
+	}
+}
+
+// get returns cached value of key or calls fetch. Concurrent callers of the same key
+// wait for the single call, each of them may stop waiting when its context is done
+func (c *CachingClient) get(
+	ctx context.Context,
+	key string,
+	ttl time.Duration,
+	fetch func(ctx context.Context) (interface{}, error),
+) (interface{}, error) {
+	c.mu.Lock()
+	if e, ok := c.entries[key]; ok && c.now().Before(e.expires) {
+		c.mu.Unlock()
+		return e.value, nil
+	}
+
+	call, ok := c.inflight[key]
+	if !ok {
+		call = &cacheCall{done: make(chan struct{})}
+		c.inflight[key] = call
+		// the call is not cancelled with the context of the first caller, since others may wait for it
+		go c.do(context.WithoutCancel(ctx), key, ttl, c.gen, call, fetch)
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.value, call.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (c *CachingClient) do(
+	ctx context.Context,
+	key string,
+	ttl time.Duration,
+	gen uint64,
+	call *cacheCall,
+	fetch func(ctx context.Context) (interface{}, error),
+) {
+	call.value, call.err = fetch(ctx)
+
+	c.mu.Lock()
+	if c.inflight[key] == call {
+		delete(c.inflight, key)
+	}
+	if call.err == nil && ttl > 0 && c.gen == gen {
+		c.entries[key] = cacheEntry{value: call.value, expires: c.now().Add(ttl)}
+	}
+	c.mu.Unlock()
+
+	close(call.done)
+}
+
+func domainCacheKey(domainID uint64) string {
+	return fmt.Sprintf("domain/%d", domainID)
+}
+
+// copyDomain returns copy of domain which records could be changed by caller
+func copyDomain(d Domain) Domain {
+	if d.LinkedRecords != nil {
+		d.LinkedRecords = append([]Record(nil), d.LinkedRecords...)
+	}
+	return d
+}
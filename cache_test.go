@@ -0,0 +1,186 @@
+package dns1cloud_test
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/reinventer/dns1cloud"
+	"github.com/reinventer/dns1cloud/dns1cloudtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var cacheRecord = dns1cloud.Record{TypeRecord: dns1cloud.RecordTypeA, HostName: "@", IP: "1.1.1.1"}
+
+func TestCachingClient(t *testing.T) {
+	srv := newTestServer(cacheRecord)
+	defer srv.Close()
+
+	now := time.Now()
+	c := dns1cloud.NewCachingClient(srv.Client(), dns1cloud.WithListTTL(time.Minute), dns1cloud.WithDomainTTL(10*time.Second))
+	dns1cloud.SetCacheClock(c, func() time.Time { return now })
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		_, err := c.List(ctx)
+		require.NoError(t, err)
+		domain, err := c.GetDomain(ctx, testDomainID)
+		require.NoError(t, err)
+		require.Len(t, domain.LinkedRecords, 1)
+
+		// changes of the result do not affect the cache
+		domain.LinkedRecords[0].IP = "2.2.2.2"
+	}
+	assert.Equal(t, 2, srv.Requests())
+
+	domain, err := c.GetDomain(ctx, testDomainID)
+	require.NoError(t, err)
+	assert.Equal(t, "1.1.1.1", domain.LinkedRecords[0].IP)
+
+	// errors are not cached
+	for i := 0; i < 2; i++ {
+		_, err = c.GetDomain(ctx, 100500)
+		assert.True(t, dns1cloud.IsNotFound(err))
+	}
+	assert.Equal(t, 4, srv.Requests())
+
+	// the domain expires earlier than the list
+	now = now.Add(20 * time.Second)
+	_, err = c.List(ctx)
+	require.NoError(t, err)
+	_, err = c.GetDomain(ctx, testDomainID)
+	require.NoError(t, err)
+	assert.Equal(t, 5, srv.Requests())
+
+	// changes of records invalidate the domain and the list
+	_, err = c.AddRecord(ctx, testDomainID, dns1cloud.Record{TypeRecord: dns1cloud.RecordTypeA, HostName: "@", IP: "1.1.1.2"})
+	require.NoError(t, err)
+	_, err = c.List(ctx)
+	require.NoError(t, err)
+	domain, err = c.GetDomain(ctx, testDomainID)
+	require.NoError(t, err)
+	assert.Len(t, domain.LinkedRecords, 2)
+	assert.Equal(t, 8, srv.Requests())
+
+	// explicit invalidation
+	c.InvalidateList()
+	_, err = c.List(ctx)
+	require.NoError(t, err)
+	_, err = c.GetDomain(ctx, testDomainID)
+	require.NoError(t, err)
+	assert.Equal(t, 9, srv.Requests())
+
+	c.InvalidateAll()
+	_, err = c.GetDomain(ctx, testDomainID)
+	require.NoError(t, err)
+	assert.Equal(t, 10, srv.Requests())
+}
+
+func TestCachingClient_disabled(t *testing.T) {
+	srv := newTestServer(cacheRecord)
+	defer srv.Close()
+
+	c := dns1cloud.NewCachingClient(srv.Client(), dns1cloud.WithListTTL(0))
+	for i := 0; i < 2; i++ {
+		_, err := c.List(context.Background())
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 2, srv.Requests())
+}
+
+func TestCachingClient_FindZoneForFQDN(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	sub := srv.AddDomain("sub.domain.com")
+
+	c := dns1cloud.NewCachingClient(srv.Client())
+	for _, fqdn := range []string{"www.sub.domain.com.", "Sub.Domain.com"} {
+		domain, err := c.FindZoneForFQDN(context.Background(), fqdn)
+		require.NoError(t, err)
+		assert.Equal(t, sub.ID, domain.ID)
+	}
+	assert.Equal(t, 1, srv.Requests())
+
+	_, err := c.FindZoneForFQDN(context.Background(), "other.com")
+	assert.True(t, dns1cloud.IsNotFound(err))
+}
+
+func TestCachingClient_singleflight(t *testing.T) {
+	srv := newTestServer(cacheRecord)
+	defer srv.Close()
+	srv.InjectFault(dns1cloudtest.Fault{Method: http.MethodGet, Latency: 100 * time.Millisecond})
+
+	c := dns1cloud.NewCachingClient(srv.Client())
+
+	// the caller with cancelled context stops waiting, the others get the result
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 1)
+	go func() {
+		_, err := c.GetDomain(ctx, testDomainID)
+		errs <- err
+	}()
+	for srv.Requests() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			domain, err := c.GetDomain(context.Background(), testDomainID)
+			assert.NoError(t, err)
+			assert.Equal(t, uint64(testDomainID), domain.ID)
+		}()
+	}
+
+	cancel()
+	assert.Equal(t, context.Canceled, <-errs)
+	wg.Wait()
+
+	assert.Equal(t, 1, srv.Requests())
+}
+
+func TestCachingClient_writesInvalidateDomain(t *testing.T) {
+	srv := newTestServer(cacheRecord)
+	defer srv.Close()
+
+	c := dns1cloud.NewCachingClient(srv.Client())
+	ctx := context.Background()
+
+	recordsOf := func() []dns1cloud.Record {
+		domain, err := c.GetDomain(ctx, testDomainID)
+		require.NoError(t, err)
+		return domain.LinkedRecords
+	}
+	require.Len(t, recordsOf(), 1)
+
+	// batch
+	results, err := c.AddRecords(ctx, testDomainID, []dns1cloud.Record{
+		{TypeRecord: dns1cloud.RecordTypeA, HostName: "www", IP: "1.1.1.2"},
+		{TypeRecord: dns1cloud.RecordTypeTXT, HostName: "@", Text: "hello"},
+	})
+	require.NoError(t, err)
+	require.Len(t, recordsOf(), 3)
+
+	// change set
+	_, err = c.NewChangeSet(testDomainID).Delete(results[1].Record.ID).Apply(ctx)
+	require.NoError(t, err)
+	require.Len(t, recordsOf(), 2)
+
+	// sync plan
+	plan, err := c.PlanSync(ctx, testDomainID, []dns1cloud.Record{
+		{TypeRecord: dns1cloud.RecordTypeA, HostName: "@", IP: "1.1.1.1"},
+	})
+	require.NoError(t, err)
+	_, err = plan.Apply(ctx, false)
+	require.NoError(t, err)
+
+	records := recordsOf()
+	if assert.Len(t, records, 1) {
+		assert.Equal(t, "@", records[0].HostName)
+	}
+}
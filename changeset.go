@@ -0,0 +1,150 @@
+package dns1cloud
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// rollbackTimeout limits time spent on reverting changes of failed ChangeSet
+var rollbackTimeout = 30 * time.Second
+
+// ChangeSet is a list of changes of records of domain which are applied in order
+// and reverted if any of them fails
+type ChangeSet struct {
+	DomainID uint64
+	Changes  []Change
+
+	client recordClient
+}
+
+// NewChangeSet creates and returns new empty ChangeSet of domain
+func (c *DNS1Cloud) NewChangeSet(domainID uint64) *ChangeSet {
+	return &ChangeSet{
+		DomainID: domainID,
+		client:   c,
+	}
+}
+
+// Add adds creation of record to the change set
+func (s *ChangeSet) Add(record Record) *ChangeSet {
+	s.Changes = append(s.Changes, Change{Action: ChangeCreate, Desired: record})
+	return s
+}
+
+// Update adds update of record to the change set, the record is identified by its ID
+func (s *ChangeSet) Update(record Record) *ChangeSet {
+	s.Changes = append(s.Changes, Change{Action: ChangeUpdate, Current: Record{ID: record.ID}, Desired: record})
+	return s
+}
+
+// Delete adds deletion of record to the change set
+func (s *ChangeSet) Delete(recordID uint64) *ChangeSet {
+	s.Changes = append(s.Changes, Change{Action: ChangeDelete, Current: Record{ID: recordID}})
+	return s
+}
+
+// ChangeSetError is returned by ChangeSet.Apply when a change fails
+type ChangeSetError struct {
+	// Index is index of the failed change
+	Index int
+	// Change is the failed change
+	Change Change
+	// Err is error of the change
+	Err error
+	// RollbackErrors are errors of reverting of applied changes, it is empty if all of them were reverted
+	RollbackErrors []error
+}
+
+// Error implements error interface
+func (e *ChangeSetError) Error() string {
+	msg := fmt.Sprintf("change %d (%s) failed: %s", e.Index+1, e.Change.Action, e.Err)
+	if len(e.RollbackErrors) == 0 {
+		return msg
+	}
+
+	msgs := make([]string, len(e.RollbackErrors))
+	for i, err := range e.RollbackErrors {
+		msgs[i] = err.Error()
+	}
+	return msg + "; rollback failed: " + strings.Join(msgs, "; ")
+}
+
+// Cause returns error of the failed change
+func (e *ChangeSetError) Cause() error {
+	return e.Err
+}
+
+// Apply fetches the current state of updated and deleted records by GetRecord and applies changes in order.
+// If a change fails, applied changes are reverted in reverse order: created records are deleted,
+// updated records get their previous state and deleted records are created again with new IDs.
+// The error is *ChangeSetError unless the state of records could not be fetched, nothing is changed then.
+// Results contain the applied changes with records returned by API
+func (s *ChangeSet) Apply(ctx context.Context) ([]ChangeResult, error) {
+	changes := make([]Change, len(s.Changes))
+	for i, ch := range s.Changes {
+		if ch.Action != ChangeCreate {
+			cur, err := s.client.GetRecord(ctx, ch.Current.ID)
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not get state of record %d", ch.Current.ID)
+			}
+			ch.Current = cur
+		}
+		changes[i] = ch
+	}
+
+	results := make([]ChangeResult, 0, len(changes))
+	for i, ch := range changes {
+		res := ChangeResult{Change: ch}
+		switch ch.Action {
+		case ChangeCreate:
+			res.Record, res.Err = s.client.AddRecord(ctx, s.DomainID, ch.Desired)
+		case ChangeUpdate:
+			res.Record, res.Err = s.client.UpdateRecord(ctx, s.DomainID, ch.Desired)
+		case ChangeDelete:
+			res.Record, res.Err = ch.Current, s.client.DeleteRecord(ctx, s.DomainID, ch.Current.ID)
+		}
+
+		if res.Err != nil {
+			return results, &ChangeSetError{
+				Index:          i,
+				Change:         ch,
+				Err:            res.Err,
+				RollbackErrors: s.rollback(ctx, results),
+			}
+		}
+		results = append(results, res)
+	}
+	return results, nil
+}
+
+// rollback reverts applied changes in reverse order. Changes are reverted even if ctx is done,
+// values of ctx are kept but the rollback is limited by rollbackTimeout instead
+func (s *ChangeSet) rollback(ctx context.Context, applied []ChangeResult) []error {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), rollbackTimeout)
+	defer cancel()
+
+	var errs []error
+	for i := len(applied) - 1; i >= 0; i-- {
+		var (
+			res = applied[i]
+			err error
+		)
+		switch res.Change.Action {
+		case ChangeCreate:
+			err = s.client.DeleteRecord(ctx, s.DomainID, res.Record.ID)
+		case ChangeUpdate:
+			_, err = s.client.UpdateRecord(ctx, s.DomainID, res.Change.Current)
+		case ChangeDelete:
+			_, err = s.client.AddRecord(ctx, s.DomainID, res.Change.Current)
+		}
+		if err != nil {
+			errs = append(errs, errors.Wrapf(err, "could not revert change %d (%s) of record %d",
+				i+1, res.Change.Action, res.Record.ID))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,179 @@
+package dns1cloud_test
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/reinventer/dns1cloud"
+	"github.com/reinventer/dns1cloud/dns1cloudtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// changeSetRecords are records of domain with IDs 1..3
+var changeSetRecords = []dns1cloud.Record{
+	{TypeRecord: dns1cloud.RecordTypeA, HostName: "@", IP: "1.1.1.1"},
+	{TypeRecord: dns1cloud.RecordTypeTXT, HostName: "@", Text: "verification=old"},
+	{TypeRecord: dns1cloud.RecordTypeTXT, HostName: "old", Text: "old"},
+}
+
+// changeSetState returns records of domain sorted by content, IDs are omitted
+func changeSetState(srv *dns1cloudtest.Server) []string {
+	d, _ := srv.Domain(testDomainID)
+
+	var res []string
+	for _, r := range d.LinkedRecords {
+		res = append(res, r.TypeRecord.String()+" "+r.HostName+" "+r.IP+r.Text)
+	}
+	sort.Strings(res)
+	return res
+}
+
+var changeSetInitialState = []string{"A @ 1.1.1.1", "TXT @ verification=old", "TXT old old"}
+
+func TestChangeSet_Apply(t *testing.T) {
+	testCases := []struct {
+		name       string
+		faults     []dns1cloudtest.Fault
+		build      func(cs *dns1cloud.ChangeSet)
+		expState   []string
+		expResults int
+		expErr     string
+		expIndex   int
+		expRBErrs  int
+	}{
+		{
+			name: "success",
+			build: func(cs *dns1cloud.ChangeSet) {
+				cs.Update(dns1cloud.Record{ID: 1, TypeRecord: dns1cloud.RecordTypeA, HostName: "@", IP: "2.2.2.2"}).
+					Delete(2).
+					Add(dns1cloud.Record{TypeRecord: dns1cloud.RecordTypeTXT, HostName: "@", Text: "verification=new"})
+			},
+			expState:   []string{"A @ 2.2.2.2", "TXT @ verification=new", "TXT old old"},
+			expResults: 3,
+		},
+		{
+			name:   "rollback",
+			faults: []dns1cloudtest.Fault{{Method: http.MethodPost, PathPrefix: "/dns/recorda", Status: http.StatusBadRequest}},
+			build: func(cs *dns1cloud.ChangeSet) {
+				cs.Update(dns1cloud.Record{ID: 1, TypeRecord: dns1cloud.RecordTypeA, HostName: "@", IP: "2.2.2.2"}).
+					Delete(2).
+					Add(dns1cloud.Record{TypeRecord: dns1cloud.RecordTypeTXT, HostName: "www", Text: "new"}).
+					Add(dns1cloud.Record{TypeRecord: dns1cloud.RecordTypeA, HostName: "www", IP: "3.3.3.3"})
+			},
+			expState:   changeSetInitialState,
+			expResults: 3,
+			expErr:     "change 4 (create) failed: bad response, status: 400, body: '" + badRequestBody + "'",
+			expIndex:   3,
+		},
+		{
+			name: "rollback fails",
+			faults: []dns1cloudtest.Fault{
+				{Method: http.MethodPut, Status: http.StatusBadRequest, Times: 1},
+				{Method: http.MethodPost, Status: http.StatusBadRequest, Times: 1},
+			},
+			build: func(cs *dns1cloud.ChangeSet) {
+				cs.Delete(3).
+					Update(dns1cloud.Record{ID: 1, TypeRecord: dns1cloud.RecordTypeA, HostName: "@", IP: "2.2.2.2"})
+			},
+			expState:   []string{"A @ 1.1.1.1", "TXT @ verification=old"},
+			expResults: 1,
+			expErr: "change 2 (update) failed: bad response, status: 400, body: '" + badRequestBody + "'; " +
+				"rollback failed: could not revert change 1 (delete) of record 3: bad response, status: 400, body: '" + badRequestBody + "'",
+			expIndex:  1,
+			expRBErrs: 1,
+		},
+		{
+			name: "unknown record",
+			build: func(cs *dns1cloud.ChangeSet) {
+				cs.Add(dns1cloud.Record{TypeRecord: dns1cloud.RecordTypeTXT, HostName: "www", Text: "new"}).Delete(100)
+			},
+			expState: changeSetInitialState,
+			expErr: "could not get state of record 100: could not send command get_record: " +
+				`bad response, status: 404, body: '{"Message":"Record is not found"}` + "\n'",
+			expIndex: -1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := newTestServer(changeSetRecords...)
+			defer srv.Close()
+			for _, f := range tc.faults {
+				srv.InjectFault(f)
+			}
+
+			cs := srv.Client().NewChangeSet(testDomainID)
+			tc.build(cs)
+
+			results, err := cs.Apply(context.Background())
+			assert.Len(t, results, tc.expResults)
+			assert.Equal(t, tc.expState, changeSetState(srv))
+
+			if len(tc.expErr) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tc.expErr)
+
+			csErr, ok := err.(*dns1cloud.ChangeSetError)
+			if tc.expIndex < 0 {
+				assert.False(t, ok)
+				return
+			}
+			require.True(t, ok)
+			assert.Equal(t, tc.expIndex, csErr.Index)
+			assert.Len(t, csErr.RollbackErrors, tc.expRBErrs)
+			assert.True(t, dns1cloud.IsBadRequest(err))
+		})
+	}
+}
+
+func TestChangeSet_Apply_cancelledContext(t *testing.T) {
+	srv := newTestServer(changeSetRecords...)
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c := srv.Client(dns1cloud.WithHTTPClient(&http.Client{
+		Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			// the context is cancelled after the first change is applied
+			if r.Method == http.MethodPost {
+				cancel()
+			}
+			return http.DefaultTransport.RoundTrip(r)
+		}),
+	}))
+
+	_, err := c.NewChangeSet(testDomainID).
+		Update(dns1cloud.Record{ID: 1, TypeRecord: dns1cloud.RecordTypeA, HostName: "@", IP: "2.2.2.2"}).
+		Add(dns1cloud.Record{TypeRecord: dns1cloud.RecordTypeTXT, HostName: "www", Text: "new"}).
+		Apply(ctx)
+	require.Error(t, err)
+	assert.Empty(t, err.(*dns1cloud.ChangeSetError).RollbackErrors)
+	assert.Equal(t, changeSetInitialState, changeSetState(srv))
+}
+
+func TestChangeSet_Apply_rollbackTimeout(t *testing.T) {
+	defer dns1cloud.SetRollbackTimeout(20 * time.Millisecond)()
+
+	srv := newTestServer(changeSetRecords...)
+	defer srv.Close()
+	srv.InjectFault(dns1cloudtest.Fault{Method: http.MethodPut, Status: http.StatusBadRequest})
+	srv.InjectFault(dns1cloudtest.Fault{Method: http.MethodDelete, Latency: time.Second})
+
+	started := time.Now()
+	_, err := srv.Client().NewChangeSet(testDomainID).
+		Add(dns1cloud.Record{TypeRecord: dns1cloud.RecordTypeTXT, HostName: "www", Text: "new"}).
+		Update(dns1cloud.Record{ID: 1, TypeRecord: dns1cloud.RecordTypeA, HostName: "@", IP: "2.2.2.2"}).
+		Apply(context.Background())
+	require.Error(t, err)
+	assert.True(t, time.Since(started) < 500*time.Millisecond)
+
+	rbErrs := err.(*dns1cloud.ChangeSetError).RollbackErrors
+	if assert.Len(t, rbErrs, 1) {
+		assert.Contains(t, rbErrs[0].Error(), "context deadline exceeded")
+	}
+}
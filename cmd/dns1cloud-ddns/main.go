@@ -0,0 +1,127 @@
+// Command dns1cloud-ddns keeps A and AAAA records of 1Cloud's DNS hosting
+// pointed at the current IP address of the host.
+//
+// Usage:
+//
+//	dns1cloud-ddns [flags] <host name> <domain name>
+//
+// The address is detected by HTTP echo endpoints or by local network interface,
+// the record is updated only when the address is changed. API key is taken from
+// DNS1CLOUD_API_KEY environment variable, API host may be overridden by DNS1CLOUD_API_HOST.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/reinventer/dns1cloud"
+	"github.com/reinventer/dns1cloud/ddns"
+)
+
+const (
+	envAPIKey  = "DNS1CLOUD_API_KEY"
+	envAPIHost = "DNS1CLOUD_API_HOST"
+)
+
+const usage = `Usage: dns1cloud-ddns [flags] <host name> <domain name>
+
+Flags:
+`
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := run(ctx, os.Args[1:], os.Stderr, os.Getenv); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string, stderr io.Writer, getenv func(string) string) error {
+	fs := flag.NewFlagSet("dns1cloud-ddns", flag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+
+	var (
+		ipv4URL   = fs.String("ipv4-url", "https://api.ipify.org", "echo endpoint returning IPv4 address, empty disables A record")
+		ipv6URL   = fs.String("ipv6-url", "", "echo endpoint returning IPv6 address, e.g. https://api6.ipify.org, empty disables AAAA record")
+		iface     = fs.String("iface", "", "take addresses from the network interface instead of echo endpoints")
+		ipv6      = fs.Bool("ipv6", false, "update AAAA record by address of the interface set by -iface")
+		ttl       = fs.Uint("ttl", 0, "TTL of records, zero keeps TTL of existing record")
+		interval  = fs.Duration("interval", 5*time.Minute, "interval between checks of the address")
+		statePath = fs.String("state", "", "path to state file, the state is kept in memory if empty")
+		once      = fs.Bool("once", false, "check the address once and exit")
+	)
+	if err := fs.Parse(args); err != nil || fs.NArg() != 2 {
+		fmt.Fprint(stderr, usage)
+		fs.SetOutput(stderr)
+		fs.PrintDefaults()
+		if err == nil {
+			err = errors.New("host name and domain name are required")
+		}
+		return err
+	}
+
+	if *ttl != 0 && !dns1cloud.IsValidTTL(uint32(*ttl)) {
+		return errors.Errorf("TTL %d is not valid", *ttl)
+	}
+	if *interval <= 0 && !*once {
+		return errors.Errorf("interval %s is not positive", *interval)
+	}
+
+	apiKey := getenv(envAPIKey)
+	if len(apiKey) == 0 {
+		return errors.Errorf("API key is not set, use %s environment variable", envAPIKey)
+	}
+	var clientOpts []dns1cloud.OptFunc
+	if host := getenv(envAPIHost); len(host) > 0 {
+		clientOpts = append(clientOpts, dns1cloud.WithApiHost(host))
+	}
+	c := dns1cloud.New(apiKey, clientOpts...)
+
+	domain, err := c.FindDomainByName(ctx, fs.Arg(1))
+	if err != nil {
+		return errors.Wrap(err, "could not find domain")
+	}
+
+	opts := []ddns.OptFunc{
+		ddns.WithTTL(uint32(*ttl)),
+		ddns.WithErrorHandler(func(err error) {
+			fmt.Fprintf(stderr, "%s: %s\n", time.Now().Format(time.RFC3339), err)
+		}),
+	}
+	switch {
+	case len(*iface) > 0 && *ipv6:
+		opts = append(opts, ddns.WithIPv6Source(&ddns.InterfaceSource{Name: *iface, IPv6: true}))
+	case len(*iface) > 0:
+		opts = append(opts, ddns.WithIPv4Source(&ddns.InterfaceSource{Name: *iface}))
+	default:
+		if len(*ipv4URL) > 0 {
+			opts = append(opts, ddns.WithIPv4Source(&ddns.HTTPSource{URL: *ipv4URL}))
+		}
+		if len(*ipv6URL) > 0 {
+			opts = append(opts, ddns.WithIPv6Source(&ddns.HTTPSource{URL: *ipv6URL}))
+		}
+	}
+	if len(*statePath) > 0 {
+		opts = append(opts, ddns.WithStateStore(&ddns.FileStore{Path: *statePath}))
+	}
+
+	u := ddns.New(c, domain.ID, fs.Arg(0), opts...)
+	if *once {
+		return u.Update(ctx)
+	}
+
+	if err = u.Run(ctx, *interval); err != nil && err != context.Canceled {
+		return err
+	}
+	return nil
+}
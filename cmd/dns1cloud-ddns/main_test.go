@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/reinventer/dns1cloud"
+	"github.com/reinventer/dns1cloud/dns1cloudtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	srv := dns1cloudtest.NewServer("key")
+	defer srv.Close()
+	d := srv.AddDomain("domain.com")
+
+	echo := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("1.2.3.4"))
+	}))
+	defer echo.Close()
+
+	getenv := func(name string) string {
+		return map[string]string{envAPIKey: "key", envAPIHost: srv.URL}[name]
+	}
+
+	testCases := []struct {
+		name string
+		args []string
+		env  func(string) string
+		err  string
+	}{
+		{
+			name: "no arguments",
+			env:  getenv,
+			err:  "host name and domain name are required",
+		},
+		{
+			name: "no API key",
+			args: []string{"-once", "home", "domain.com"},
+			env:  func(string) string { return "" },
+			err:  "API key is not set, use DNS1CLOUD_API_KEY environment variable",
+		},
+		{
+			name: "invalid TTL",
+			args: []string{"-once", "-ttl", "7", "home", "domain.com"},
+			env:  getenv,
+			err:  "TTL 7 is not valid",
+		},
+		{
+			name: "zero interval",
+			args: []string{"-interval", "0", "home", "domain.com"},
+			env:  getenv,
+			err:  "interval 0s is not positive",
+		},
+		{
+			name: "unknown domain",
+			args: []string{"-once", "home", "other.com"},
+			env:  getenv,
+			err:  `could not find domain: domain "other.com" is not found`,
+		},
+		{
+			name: "success",
+			args: []string{"-once", "-ipv4-url", echo.URL, "-ttl", "600", "home", "domain.com"},
+			env:  getenv,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := run(context.Background(), tc.args, &bytes.Buffer{}, tc.env)
+			if len(tc.err) > 0 {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+
+	domain, _ := srv.Domain(d.ID)
+	require.Len(t, domain.LinkedRecords, 1)
+	assert.Equal(t, dns1cloud.RecordTypeA, domain.LinkedRecords[0].TypeRecord)
+	assert.Equal(t, "home", domain.LinkedRecords[0].HostName)
+	assert.Equal(t, "1.2.3.4", domain.LinkedRecords[0].IP)
+	assert.Equal(t, uint32(600), domain.LinkedRecords[0].TTL)
+}
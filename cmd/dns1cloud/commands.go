@@ -0,0 +1,213 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/reinventer/dns1cloud"
+)
+
+func newFlagSet(name string, out *printer) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	fs.Usage = func() {
+		fs.SetOutput(out.w)
+		fmt.Fprintf(out.w, "Usage of %s:\n", name)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return err
+		}
+		return usageErrorf("%s", err)
+	}
+	if fs.NArg() > 0 {
+		return usageErrorf("unexpected arguments: %v", fs.Args())
+	}
+	return nil
+}
+
+func listCommand(ctx context.Context, c *dns1cloud.DNS1Cloud, args []string, out *printer) error {
+	fs := newFlagSet("list", out)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	domains, err := c.List(ctx)
+	if err != nil {
+		return err
+	}
+	return out.domains(domains)
+}
+
+func getDomainCommand(ctx context.Context, c *dns1cloud.DNS1Cloud, args []string, out *printer) error {
+	fs := newFlagSet("get-domain", out)
+	domainID := fs.Uint64("domain", 0, "ID of domain (required)")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *domainID == 0 {
+		return usageErrorf("flag -domain is required")
+	}
+
+	domain, err := c.GetDomain(ctx, *domainID)
+	if err != nil {
+		return err
+	}
+	return out.domain(domain)
+}
+
+func getRecordCommand(ctx context.Context, c *dns1cloud.DNS1Cloud, args []string, out *printer) error {
+	fs := newFlagSet("get-record", out)
+	recordID := fs.Uint64("id", 0, "ID of record (required)")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *recordID == 0 {
+		return usageErrorf("flag -id is required")
+	}
+
+	record, err := c.GetRecord(ctx, *recordID)
+	if err != nil {
+		return err
+	}
+	return out.record(record)
+}
+
+func addRecordCommand(ctx context.Context, c *dns1cloud.DNS1Cloud, args []string, out *printer) error {
+	fs := newFlagSet("add-record", out)
+	domainID := fs.Uint64("domain", 0, "ID of domain (required)")
+	rf := newRecordFlags(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *domainID == 0 {
+		return usageErrorf("flag -domain is required")
+	}
+
+	record, err := rf.record()
+	if err != nil {
+		return err
+	}
+
+	record, err = c.AddRecord(ctx, *domainID, record)
+	if err != nil {
+		return err
+	}
+	return out.record(record)
+}
+
+func updateRecordCommand(ctx context.Context, c *dns1cloud.DNS1Cloud, args []string, out *printer) error {
+	fs := newFlagSet("update-record", out)
+	domainID := fs.Uint64("domain", 0, "ID of domain (required)")
+	recordID := fs.Uint64("id", 0, "ID of record (required)")
+	rf := newRecordFlags(fs)
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *domainID == 0 || *recordID == 0 {
+		return usageErrorf("flags -domain and -id are required")
+	}
+
+	record, err := rf.record()
+	if err != nil {
+		return err
+	}
+	record.ID = *recordID
+
+	record, err = c.UpdateRecord(ctx, *domainID, record)
+	if err != nil {
+		return err
+	}
+	return out.record(record)
+}
+
+func deleteRecordCommand(ctx context.Context, c *dns1cloud.DNS1Cloud, args []string, out *printer) error {
+	fs := newFlagSet("delete-record", out)
+	domainID := fs.Uint64("domain", 0, "ID of domain (required)")
+	recordID := fs.Uint64("id", 0, "ID of record (required)")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *domainID == 0 || *recordID == 0 {
+		return usageErrorf("flags -domain and -id are required")
+	}
+
+	return c.DeleteRecord(ctx, *domainID, *recordID)
+}
+
+// recordFlags are flags describing record for add-record and update-record commands
+type recordFlags struct {
+	typ          *string
+	hostName     *string
+	ip           *string
+	text         *string
+	mnemonicName *string
+	extHostName  *string
+	priority     *string
+	weight       *string
+	port         *string
+	target       *string
+	proto        *string
+	service      *string
+	flag         *string
+	tag          *string
+	value        *string
+	ttl          *uint
+}
+
+func newRecordFlags(fs *flag.FlagSet) *recordFlags {
+	return &recordFlags{
+		typ:          fs.String("type", "", "type of record: A, AAAA, CNAME, MX, NS, TXT, SRV, CAA or PTR (required)"),
+		hostName:     fs.String("host", "", "host name"),
+		ip:           fs.String("ip", "", "IP address of A and AAAA records"),
+		text:         fs.String("text", "", "text of TXT record"),
+		mnemonicName: fs.String("mnemonic", "", "alias of CNAME record"),
+		extHostName:  fs.String("ext-host", "", "subdomain of NS record"),
+		priority:     fs.String("priority", "", "priority of MX and SRV records"),
+		weight:       fs.String("weight", "", "weight of SRV record"),
+		port:         fs.String("port", "", "port of SRV record"),
+		target:       fs.String("target", "", "target of SRV and PTR records"),
+		proto:        fs.String("proto", "", "protocol of SRV record"),
+		service:      fs.String("service", "", "service of SRV record"),
+		flag:         fs.String("flag", "", "flag of CAA record"),
+		tag:          fs.String("tag", "", "tag of CAA record"),
+		value:        fs.String("value", "", "value of CAA record"),
+		ttl:          fs.Uint("ttl", 0, "TTL of record in seconds"),
+	}
+}
+
+func (f *recordFlags) record() (dns1cloud.Record, error) {
+	if len(*f.typ) == 0 {
+		return dns1cloud.Record{}, usageErrorf("flag -type is required")
+	}
+	typ, err := dns1cloud.ParseRecordType(*f.typ)
+	if err != nil {
+		return dns1cloud.Record{}, usageErrorf("%s", err)
+	}
+
+	return dns1cloud.Record{
+		TypeRecord:   typ,
+		HostName:     *f.hostName,
+		IP:           *f.ip,
+		Text:         *f.text,
+		MnemonicName: *f.mnemonicName,
+		ExtHostName:  *f.extHostName,
+		Priority:     *f.priority,
+		Weight:       *f.weight,
+		Port:         *f.port,
+		Target:       *f.target,
+		Proto:        *f.proto,
+		Service:      *f.service,
+		Flag:         *f.flag,
+		Tag:          *f.tag,
+		Value:        *f.value,
+		TTL:          uint32(*f.ttl),
+	}, nil
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	envAPIKey  = "DNS1CLOUD_API_KEY"
+	envAPIHost = "DNS1CLOUD_API_HOST"
+)
+
+// config is content of config file
+type config struct {
+	APIKey  string `json:"api_key"`
+	APIHost string `json:"api_host"`
+}
+
+func defaultConfigPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "dns1cloud", "config.json")
+}
+
+// loadConfig reads config file and overrides its values by environment variables.
+// Missing default config file is not an error
+func loadConfig(path string, explicit bool, getenv func(string) string) (config, error) {
+	var cfg config
+
+	if len(path) > 0 {
+		b, err := ioutil.ReadFile(path)
+		switch {
+		case os.IsNotExist(err) && !explicit:
+		case err != nil:
+			return cfg, errors.Wrap(err, "could not read config file")
+		default:
+			if err = json.Unmarshal(b, &cfg); err != nil {
+				return cfg, errors.Wrapf(err, "could not parse config file %s", path)
+			}
+		}
+	}
+
+	if v := getenv(envAPIKey); len(v) > 0 {
+		cfg.APIKey = v
+	}
+	if v := getenv(envAPIHost); len(v) > 0 {
+		cfg.APIHost = v
+	}
+
+	if len(cfg.APIKey) == 0 {
+		return cfg, errors.Errorf("API key is not set, use %s environment variable or config file", envAPIKey)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,30 @@
+// Command dns1cloud manages domains and records of 1Cloud's DNS hosting.
+//
+// Usage:
+//
+//	dns1cloud [-output table|json|yaml] [-config file] <command> [flags]
+//
+// Commands are list, get-domain, get-record, add-record, update-record and delete-record.
+// API key is taken from DNS1CLOUD_API_KEY environment variable or from "api_key"
+// field of JSON config file, by default $XDG_CONFIG_HOME/dns1cloud/config.json.
+//
+// Exit codes:
+//
+//	0 success
+//	1 unclassified error
+//	2 bad usage
+//	3 domain or record is not found
+//	4 API key is rejected
+//	5 request is rejected by API as invalid
+//	6 conflict with existing domain or record
+//	7 rate limit is exceeded
+//	8 API server error
+package main
+
+import (
+	"os"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr, os.Getenv))
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	"github.com/reinventer/dns1cloud"
+)
+
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatYAML  = "yaml"
+)
+
+// printer prints results of commands in chosen format
+type printer struct {
+	w      io.Writer
+	format string
+}
+
+func newPrinter(w io.Writer, format string) (*printer, error) {
+	switch format {
+	case formatTable, formatJSON, formatYAML:
+		return &printer{w: w, format: format}, nil
+	}
+	return nil, errors.Errorf("unknown output format %q", format)
+}
+
+func (p *printer) domains(domains []dns1cloud.Domain) error {
+	if p.format != formatTable {
+		return p.encode(domains)
+	}
+
+	tw := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tNAME\tSTATE\tRECORDS")
+	for _, d := range domains {
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%d\n", d.ID, d.Name, d.State, len(d.LinkedRecords))
+	}
+	return tw.Flush()
+}
+
+func (p *printer) domain(domain dns1cloud.Domain) error {
+	if p.format != formatTable {
+		return p.encode(domain)
+	}
+
+	fmt.Fprintf(p.w, "ID:       %d\nName:     %s\nState:    %s\nDelegate: %t\n\n", domain.ID, domain.Name, domain.State, domain.IsDelegate)
+	return p.recordsTable(domain.LinkedRecords)
+}
+
+func (p *printer) record(record dns1cloud.Record) error {
+	if p.format != formatTable {
+		return p.encode(record)
+	}
+	return p.recordsTable([]dns1cloud.Record{record})
+}
+
+func (p *printer) recordsTable(records []dns1cloud.Record) error {
+	tw := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tTYPE\tNAME\tVALUE\tTTL\tSTATE")
+	for _, r := range records {
+		name, value := describe(r)
+		typ := r.TypeRecord.String()
+		if r.TypeRecord == dns1cloud.RecordTypeUnknown {
+			typ = r.RawTypeRecord
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%d\t%s\n", r.ID, typ, name, value, r.TTL, r.State)
+	}
+	return tw.Flush()
+}
+
+// describe returns name and value of record as they are shown in table
+func describe(r dns1cloud.Record) (string, string) {
+	switch r.TypeRecord {
+	case dns1cloud.RecordTypeA, dns1cloud.RecordTypeAAAA:
+		return r.HostName, r.IP
+	case dns1cloud.RecordTypeCNAME:
+		return r.MnemonicName, r.HostName
+	case dns1cloud.RecordTypeMX:
+		return "@", r.Priority + " " + r.HostName
+	case dns1cloud.RecordTypeNS:
+		return r.ExtHostName, r.HostName
+	case dns1cloud.RecordTypeTXT:
+		return r.HostName, fmt.Sprintf("%q", r.Text)
+	case dns1cloud.RecordTypeSRV:
+		return "_" + strings.Trim(r.Service, "._") + "._" + strings.Trim(r.Proto, "._") + "." + r.HostName,
+			strings.Join([]string{r.Priority, r.Weight, r.Port, r.Target}, " ")
+	case dns1cloud.RecordTypeCAA:
+		return r.HostName, fmt.Sprintf("%s %s %q", r.Flag, r.Tag, r.Value)
+	case dns1cloud.RecordTypePTR:
+		return r.HostName, r.Target
+	}
+	return r.HostName, r.CanonicalDescription
+}
+
+func (p *printer) encode(v interface{}) error {
+	if p.format == formatJSON {
+		enc := json.NewEncoder(p.w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal result")
+	}
+	return writeYAML(p.w, b)
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/reinventer/dns1cloud"
+)
+
+// exit codes, see package documentation
+const (
+	exitOK = iota
+	exitError
+	exitUsage
+	exitNotFound
+	exitUnauthorized
+	exitBadRequest
+	exitConflict
+	exitRateLimited
+	exitServerError
+)
+
+// usageError is an error in command line arguments
+type usageError struct {
+	msg string
+}
+
+func (e *usageError) Error() string {
+	return e.msg
+}
+
+func usageErrorf(format string, args ...interface{}) error {
+	return &usageError{msg: fmt.Sprintf(format, args...)}
+}
+
+type commandFunc func(ctx context.Context, c *dns1cloud.DNS1Cloud, args []string, out *printer) error
+
+var commands = map[string]commandFunc{
+	"list":          listCommand,
+	"get-domain":    getDomainCommand,
+	"get-record":    getRecordCommand,
+	"add-record":    addRecordCommand,
+	"update-record": updateRecordCommand,
+	"delete-record": deleteRecordCommand,
+}
+
+const usage = `Usage: dns1cloud [-output table|json|yaml] [-config file] <command> [flags]
+
+Commands:
+  list            list domains
+  get-domain      show domain with its records
+  get-record      show record
+  add-record      add record to domain
+  update-record   update record of domain
+  delete-record   delete record of domain
+
+Run "dns1cloud <command> -h" for flags of the command.
+`
+
+func run(args []string, stdout, stderr io.Writer, getenv func(string) string) int {
+	fs := flag.NewFlagSet("dns1cloud", flag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+
+	var (
+		output     = fs.String("output", "table", "output format: table, json or yaml")
+		configPath = fs.String("config", "", "path to config file")
+	)
+	if err := fs.Parse(args); err != nil {
+		fmt.Fprintf(stderr, "%s\n\n%s", err, usage)
+		return exitUsage
+	}
+
+	if fs.NArg() == 0 {
+		fmt.Fprint(stderr, usage)
+		return exitUsage
+	}
+
+	cmd, ok := commands[fs.Arg(0)]
+	if !ok {
+		fmt.Fprintf(stderr, "unknown command %q\n\n%s", fs.Arg(0), usage)
+		return exitUsage
+	}
+
+	out, err := newPrinter(stdout, *output)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitUsage
+	}
+
+	path, explicit := *configPath, true
+	if len(path) == 0 {
+		path, explicit = defaultConfigPath(), false
+	}
+	cfg, err := loadConfig(path, explicit, getenv)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitUsage
+	}
+
+	var opts []dns1cloud.OptFunc
+	if len(cfg.APIHost) > 0 {
+		opts = append(opts, dns1cloud.WithApiHost(strings.TrimSuffix(cfg.APIHost, "/")))
+	}
+	c := dns1cloud.New(cfg.APIKey, opts...)
+
+	if err = cmd(context.Background(), c, fs.Args()[1:], out); err != nil {
+		if err == flag.ErrHelp {
+			return exitOK
+		}
+		fmt.Fprintf(stderr, "%s: %s\n", fs.Arg(0), err)
+		return exitCode(err)
+	}
+	return exitOK
+}
+
+// exitCode maps error to exit code
+func exitCode(err error) int {
+	if _, ok := errors.Cause(err).(*usageError); ok {
+		return exitUsage
+	}
+
+	switch {
+	case dns1cloud.IsNotFound(err):
+		return exitNotFound
+	case dns1cloud.IsUnauthorized(err), dns1cloud.IsForbidden(err):
+		return exitUnauthorized
+	case dns1cloud.IsBadRequest(err), dns1cloud.IsUnknownRecordType(err), dns1cloud.IsValidationError(err):
+		return exitBadRequest
+	case dns1cloud.IsConflict(err):
+		return exitConflict
+	case dns1cloud.IsRateLimited(err):
+		return exitRateLimited
+	case dns1cloud.IsServerError(err):
+		return exitServerError
+	}
+	return exitError
+}
@@ -0,0 +1,280 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const listJSON = `[{"ID":123,"Name":"domain.com","TechName":"domain_com","State":"Active","DateCreate":"2019-02-02T21:17:11.64","IsDelegate":false,"LinkedRecords":[{"ID":124,"TypeRecord":"A","IP":"1.1.1.1","HostName":"@","State":"Active","TTL":3600,"DateCreate":"2019-02-02T21:18:06.743"},{"ID":125,"TypeRecord":"TXT","HostName":"@","Text":"v=spf1 ~all","State":"New","TTL":600,"DateCreate":null}]}]`
+
+func TestRun(t *testing.T) {
+	testCases := []struct {
+		name           string
+		args           []string
+		apiKey         string
+		responseStatus int
+		responseJSON   string
+		expRequest     string
+		expRequestBody string
+		expCode        int
+		expStdout      string
+		expStderr      string
+	}{
+		{
+			name:           "list table",
+			args:           []string{"list"},
+			responseStatus: http.StatusOK,
+			responseJSON:   listJSON,
+			expRequest:     "GET /dns",
+			expCode:        exitOK,
+			expStdout:      "ID   NAME        STATE   RECORDS\n123  domain.com  Active  2\n",
+		},
+		{
+			name:           "list yaml",
+			args:           []string{"-output", "yaml", "list"},
+			responseStatus: http.StatusOK,
+			responseJSON:   listJSON,
+			expRequest:     "GET /dns",
+			expCode:        exitOK,
+			expStdout: `- ID: 123
+  Name: domain.com
+  TechName: domain_com
+  State: Active
+  DateCreate: "2019-02-02T21:17:11.64"
+  IsDelegate: false
+  LinkedRecords:
+    - ID: 124
+      TypeRecord: A
+      IP: "1.1.1.1"
+      HostName: "@"
+      Priority: ""
+      Text: ""
+      MnemonicName: ""
+      ExtHostName: ""
+      Weight: ""
+      Port: ""
+      Target: ""
+      Proto: ""
+      Service: ""
+      Flag: ""
+      Tag: ""
+      Value: ""
+      TTL: 3600
+      State: Active
+      DateCreate: "2019-02-02T21:18:06.743"
+      CanonicalDescription: ""
+    - ID: 125
+      TypeRecord: TXT
+      IP: ""
+      HostName: "@"
+      Priority: ""
+      Text: v=spf1 ~all
+      MnemonicName: ""
+      ExtHostName: ""
+      Weight: ""
+      Port: ""
+      Target: ""
+      Proto: ""
+      Service: ""
+      Flag: ""
+      Tag: ""
+      Value: ""
+      TTL: 600
+      State: New
+      DateCreate: null
+      CanonicalDescription: ""
+`,
+		},
+		{
+			name:           "get domain table",
+			args:           []string{"get-domain", "-domain", "123"},
+			responseStatus: http.StatusOK,
+			responseJSON:   listJSON[1 : len(listJSON)-1],
+			expRequest:     "GET /dns/123",
+			expCode:        exitOK,
+			expStdout: "ID:       123\nName:     domain.com\nState:    Active\nDelegate: false\n\n" +
+				"ID   TYPE  NAME  VALUE          TTL   STATE\n" +
+				"124  A     @     1.1.1.1        3600  Active\n" +
+				"125  TXT   @     \"v=spf1 ~all\"  600   New\n",
+		},
+		{
+			name:           "get domain not found",
+			args:           []string{"get-domain", "-domain", "123"},
+			responseStatus: http.StatusNotFound,
+			responseJSON:   `{"Message": "not found"}`,
+			expRequest:     "GET /dns/123",
+			expCode:        exitNotFound,
+			expStderr:      "get-domain: could not send command get_domain: bad response, status: 404, body: '{\"Message\": \"not found\"}'\n",
+		},
+		{
+			name:           "get record json",
+			args:           []string{"-output", "json", "get-record", "-id", "124"},
+			responseStatus: http.StatusOK,
+			responseJSON:   `{"ID":124,"TypeRecord":"A","IP":"1.1.1.1","HostName":"@","State":"Active","TTL":3600}`,
+			expRequest:     "GET /dns/record/124",
+			expCode:        exitOK,
+			expStdout: `{
+  "ID": 124,
+  "TypeRecord": "A",
+  "IP": "1.1.1.1",
+  "HostName": "@",
+  "Priority": "",
+  "Text": "",
+  "MnemonicName": "",
+  "ExtHostName": "",
+  "Weight": "",
+  "Port": "",
+  "Target": "",
+  "Proto": "",
+  "Service": "",
+  "Flag": "",
+  "Tag": "",
+  "Value": "",
+  "TTL": 3600,
+  "State": "Active",
+  "DateCreate": null,
+  "CanonicalDescription": ""
+}
+`,
+		},
+		{
+			name:           "add record",
+			args:           []string{"add-record", "-domain", "123", "-type", "txt", "-host", "@", "-text", "hello", "-ttl", "600"},
+			responseStatus: http.StatusOK,
+			responseJSON:   `{"ID":126,"TypeRecord":"TXT","HostName":"@","Text":"hello","State":"New","TTL":600}`,
+			expRequest:     "POST /dns/recordtxt",
+			expRequestBody: `{"DomainId":"123","Name":"@","Text":"hello","TTL":"600"}`,
+			expCode:        exitOK,
+			expStdout:      "ID   TYPE  NAME  VALUE    TTL  STATE\n126  TXT   @     \"hello\"  600  New\n",
+		},
+		{
+			name:           "update record rejected",
+			args:           []string{"update-record", "-domain", "123", "-id", "124", "-type", "A", "-host", "@", "-ip", "1.1.1.2"},
+			responseStatus: http.StatusBadRequest,
+			expRequest:     "PUT /dns/recorda/124",
+			expRequestBody: `{"DomainId":"123","IP":"1.1.1.2","Name":"@"}`,
+			expCode:        exitBadRequest,
+			expStderr:      "update-record: bad response, status: 400, body: ''\n",
+		},
+		{
+			name:      "invalid record",
+			args:      []string{"add-record", "-domain", "123", "-type", "A", "-host", "@", "-ip", "bogus"},
+			expCode:   exitBadRequest,
+			expStderr: "add-record: IP \"bogus\" is incorrect\n",
+		},
+		{
+			name:           "delete record unauthorized",
+			args:           []string{"delete-record", "-domain", "123", "-id", "124"},
+			responseStatus: http.StatusUnauthorized,
+			expRequest:     "DELETE /dns/123/124",
+			expCode:        exitUnauthorized,
+			expStderr:      "delete-record: could not send command delete_record: bad response, status: 401, body: ''\n",
+		},
+		{
+			name:           "rate limited",
+			args:           []string{"list"},
+			responseStatus: http.StatusTooManyRequests,
+			expRequest:     "GET /dns",
+			expCode:        exitRateLimited,
+			expStderr:      "list: could not send command list: bad response, status: 429, body: ''\n",
+		},
+		{
+			name:           "server error",
+			args:           []string{"list"},
+			responseStatus: http.StatusBadGateway,
+			expRequest:     "GET /dns",
+			expCode:        exitServerError,
+			expStderr:      "list: could not send command list: bad response, status: 502, body: ''\n",
+		},
+		{
+			name:      "missing required flag",
+			args:      []string{"get-record"},
+			expCode:   exitUsage,
+			expStderr: "get-record: flag -id is required\n",
+		},
+		{
+			name:      "unknown record type",
+			args:      []string{"add-record", "-domain", "1", "-type", "NAPTR"},
+			expCode:   exitUsage,
+			expStderr: "add-record: unknown record type \"NAPTR\"\n",
+		},
+		{
+			name:      "unknown output format",
+			args:      []string{"-output", "xml", "list"},
+			expCode:   exitUsage,
+			expStderr: "unknown output format \"xml\"\n",
+		},
+		{
+			name:      "missing API key",
+			args:      []string{"list"},
+			apiKey:    "-",
+			expCode:   exitUsage,
+			expStderr: "API key is not set, use DNS1CLOUD_API_KEY environment variable or config file\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var request string
+			s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				request = r.Method + " " + r.URL.Path
+				assert.Equal(t, "Bearer apiKey", r.Header.Get("Authorization"))
+
+				if len(tc.expRequestBody) > 0 {
+					body, err := ioutil.ReadAll(r.Body)
+					assert.NoError(t, err)
+					assert.JSONEq(t, tc.expRequestBody, string(body))
+				}
+
+				w.WriteHeader(tc.responseStatus)
+				w.Write([]byte(tc.responseJSON))
+			}))
+			defer s.Close()
+
+			env := map[string]string{
+				envAPIKey:         "apiKey",
+				envAPIHost:        s.URL,
+				"XDG_CONFIG_HOME": t.TempDir(),
+			}
+			if tc.apiKey == "-" {
+				delete(env, envAPIKey)
+			}
+
+			var stdout, stderr bytes.Buffer
+			code := run(append([]string{"-config", ""}, tc.args...), &stdout, &stderr, func(k string) string { return env[k] })
+
+			assert.Equal(t, tc.expCode, code)
+			assert.Equal(t, tc.expStdout, stdout.String())
+			assert.Equal(t, tc.expStderr, stderr.String())
+			assert.Equal(t, tc.expRequest, request)
+		})
+	}
+}
+
+func TestRun_ConfigFile(t *testing.T) {
+	var auth string
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		w.Write([]byte("[]"))
+	}))
+	defer s.Close()
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	assert.NoError(t, ioutil.WriteFile(path, []byte(`{"api_key": "fileKey", "api_host": "`+s.URL+`"}`), 0600))
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"-config", path, "-output", "json", "list"}, &stdout, &stderr, os.Getenv)
+	assert.Equal(t, exitOK, code, stderr.String())
+	assert.Equal(t, "Bearer fileKey", auth)
+	assert.Equal(t, "[]\n", stdout.String())
+
+	code = run([]string{"-config", filepath.Join(t.TempDir(), "missing.json"), "list"}, &stdout, &stderr, os.Getenv)
+	assert.Equal(t, exitUsage, code)
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// node is a JSON value keeping order of object keys
+type node struct {
+	scalar interface{}
+	keys   []string
+	values []node
+	object bool
+	array  bool
+}
+
+// writeYAML converts JSON document to YAML keeping order of object keys
+func writeYAML(w io.Writer, b []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	n, err := decodeNode(dec)
+	if err != nil {
+		return errors.Wrap(err, "could not decode JSON")
+	}
+
+	bw := bufio.NewWriter(w)
+	if n.object || n.array {
+		writeNode(bw, n, 0)
+	} else {
+		bw.WriteString(scalar(n.scalar) + "\n")
+	}
+	return bw.Flush()
+}
+
+func decodeNode(dec *json.Decoder) (node, error) {
+	t, err := dec.Token()
+	if err != nil {
+		return node{}, err
+	}
+
+	switch t {
+	case json.Delim('{'):
+		n := node{object: true}
+		for dec.More() {
+			k, err := dec.Token()
+			if err != nil {
+				return node{}, err
+			}
+			v, err := decodeNode(dec)
+			if err != nil {
+				return node{}, err
+			}
+			n.keys = append(n.keys, k.(string))
+			n.values = append(n.values, v)
+		}
+		_, err = dec.Token()
+		return n, err
+	case json.Delim('['):
+		n := node{array: true}
+		for dec.More() {
+			v, err := decodeNode(dec)
+			if err != nil {
+				return node{}, err
+			}
+			n.values = append(n.values, v)
+		}
+		_, err = dec.Token()
+		return n, err
+	}
+	return node{scalar: t}, nil
+}
+
+func writeNode(w *bufio.Writer, n node, indent int) {
+	pad := strings.Repeat("  ", indent)
+
+	if n.object {
+		if len(n.keys) == 0 {
+			w.WriteString(pad + "{}\n")
+		}
+		for i, k := range n.keys {
+			writeEntry(w, pad+scalar(k)+":", n.values[i], indent)
+		}
+		return
+	}
+
+	if len(n.values) == 0 {
+		w.WriteString(pad + "[]\n")
+	}
+	for _, v := range n.values {
+		if v.object && len(v.keys) > 0 {
+			// the first key of the object is written on the line of the dash
+			writeEntry(w, pad+"- "+scalar(v.keys[0])+":", v.values[0], indent+1)
+			for i, k := range v.keys[1:] {
+				writeEntry(w, pad+"  "+scalar(k)+":", v.values[i+1], indent+1)
+			}
+			continue
+		}
+		writeEntry(w, pad+"-", v, indent)
+	}
+}
+
+// writeEntry writes value after prefix, nested collections are written on the next lines
+func writeEntry(w *bufio.Writer, prefix string, v node, indent int) {
+	switch {
+	case v.object && len(v.keys) == 0:
+		w.WriteString(prefix + " {}\n")
+	case v.array && len(v.values) == 0:
+		w.WriteString(prefix + " []\n")
+	case v.object || v.array:
+		w.WriteString(prefix + "\n")
+		writeNode(w, v, indent+1)
+	default:
+		w.WriteString(prefix + " " + scalar(v.scalar) + "\n")
+	}
+}
+
+var (
+	plainString = regexp.MustCompile(`^[A-Za-z0-9_./(-][A-Za-z0-9_ ./@()=:,;+~-]*$`)
+	numberLike  = regexp.MustCompile(`^[-+]?(\.?[0-9]|0x|0o)`)
+	// leadingIndicator matches plain strings starting like a sequence entry
+	// or a document marker
+	leadingIndicator = regexp.MustCompile(`^(-( |$)|---|\.\.\.)`)
+)
+
+// scalar returns YAML representation of scalar JSON value
+func scalar(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return strconv.FormatBool(v)
+	case json.Number:
+		return v.String()
+	case string:
+		if needsQuotes(v) {
+			b, _ := json.Marshal(v)
+			return string(b)
+		}
+		return v
+	}
+	return ""
+}
+
+func needsQuotes(s string) bool {
+	if !plainString.MatchString(s) || numberLike.MatchString(s) || leadingIndicator.MatchString(s) ||
+		strings.HasSuffix(s, " ") || strings.HasSuffix(s, ":") || strings.Contains(s, ": ") {
+		return true
+	}
+	switch strings.ToLower(s) {
+	case "true", "false", "null", "yes", "no", "on", "off", "y", "n", "~":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScalar(t *testing.T) {
+	testCases := []struct {
+		value interface{}
+		exp   string
+	}{
+		{value: nil, exp: `null`},
+		{value: true, exp: `true`},
+		{value: json.Number("3600"), exp: `3600`},
+		{value: "domain.com", exp: `domain.com`},
+		{value: "v=spf1 ~all", exp: `v=spf1 ~all`},
+		{value: "-foo", exp: `-foo`},
+		{value: "", exp: `""`},
+		{value: "@", exp: `"@"`},
+		{value: "1.1.1.1", exp: `"1.1.1.1"`},
+		{value: "yes", exp: `"yes"`},
+		{value: "-", exp: `"-"`},
+		{value: "- foo", exp: `"- foo"`},
+		{value: "---", exp: `"---"`},
+		{value: "... end", exp: `"... end"`},
+		{value: "? foo", exp: `"? foo"`},
+		{value: ": foo", exp: `": foo"`},
+		{value: "# foo", exp: `"# foo"`},
+		{value: "[foo]", exp: `"[foo]"`},
+		{value: "*foo", exp: `"*foo"`},
+		{value: "key: value", exp: `"key: value"`},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.exp, scalar(tc.value), "value %#v", tc.value)
+	}
+}
+
+func TestWriteYAML(t *testing.T) {
+	var buf bytes.Buffer
+	err := writeYAML(&buf, []byte(`[{"ID":1,"Text":"- foo","Tags":["-",""],"Empty":{}}]`))
+	assert.NoError(t, err)
+	assert.Equal(t, `- ID: 1
+  Text: "- foo"
+  Tags:
+    - "-"
+    - ""
+  Empty: {}
+`, buf.String())
+}
@@ -0,0 +1,208 @@
+// Package ddns keeps A and AAAA records of 1Cloud's DNS hosting pointed
+// at the current IP address of the host
+package ddns
+
+import (
+	"context"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/reinventer/dns1cloud"
+)
+
+// Client is a part of dns1cloud.DNS1Cloud used by the updater
+type Client interface {
+	GetDomain(ctx context.Context, domainID uint64) (dns1cloud.Domain, error)
+	AddRecord(ctx context.Context, domainID uint64, record dns1cloud.Record) (dns1cloud.Record, error)
+	UpdateRecord(ctx context.Context, domainID uint64, record dns1cloud.Record) (dns1cloud.Record, error)
+}
+
+// Updater updates A and AAAA records of the host when its IP address is changed
+type Updater struct {
+	client   Client
+	domainID uint64
+	hostName string
+
+	ttl    uint32
+	ipv4   Source
+	ipv6   Source
+	store  StateStore
+	onErr  func(error)
+	loaded bool
+	state  State
+}
+
+// OptFunc is type for option function
+type OptFunc func(*Updater)
+
+// WithIPv4Source is option function for setting source of IPv4 address, A record is updated by it
+func WithIPv4Source(s Source) OptFunc {
+	return func(u *Updater) {
+		u.ipv4 = s
+	}
+}
+
+// WithIPv6Source is option function for setting source of IPv6 address, AAAA record is updated by it
+func WithIPv6Source(s Source) OptFunc {
+	return func(u *Updater) {
+		u.ipv6 = s
+	}
+}
+
+// WithTTL is option function for setting TTL of records
+func WithTTL(ttl uint32) OptFunc {
+	return func(u *Updater) {
+		u.ttl = ttl
+	}
+}
+
+// WithStateStore is option function for setting storage of state
+func WithStateStore(s StateStore) OptFunc {
+	return func(u *Updater) {
+		u.store = s
+	}
+}
+
+// WithErrorHandler is option function for setting handler of errors in Run
+func WithErrorHandler(f func(error)) OptFunc {
+	return func(u *Updater) {
+		u.onErr = f
+	}
+}
+
+// New creates and returns new Updater of records with host name in domain
+func New(client Client, domainID uint64, hostName string, opts ...OptFunc) *Updater {
+	u := &Updater{
+		client:   client,
+		domainID: domainID,
+		hostName: hostName,
+	}
+
+	for _, f := range opts {
+		f(u)
+	}
+
+	if u.store == nil {
+		u.store = &memoryStore{}
+	}
+	if u.onErr == nil {
+		u.onErr = func(error) {}
+	}
+
+	return u
+}
+
+// Run calls Update every interval until ctx is done
+func (u *Updater) Run(ctx context.Context, interval time.Duration) error {
+	if interval <= 0 {
+		return errors.Errorf("interval %s is not positive", interval)
+	}
+
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		if err := u.Update(ctx); err != nil {
+			u.onErr(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+// Update detects current addresses and updates records if addresses are changed
+// since the last successful update
+func (u *Updater) Update(ctx context.Context) error {
+	if !u.loaded {
+		st, err := u.store.Load()
+		if err != nil {
+			return errors.Wrap(err, "could not load state")
+		}
+		u.state, u.loaded = st, true
+	}
+
+	if u.ipv4 != nil {
+		if err := u.update(ctx, dns1cloud.RecordTypeA, u.ipv4, &u.state.IPv4); err != nil {
+			return err
+		}
+	}
+	if u.ipv6 != nil {
+		if err := u.update(ctx, dns1cloud.RecordTypeAAAA, u.ipv6, &u.state.IPv6); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (u *Updater) update(ctx context.Context, typ dns1cloud.RecordType, src Source, last *string) error {
+	ip, err := src.Detect(ctx)
+	if err != nil {
+		return errors.Wrapf(err, "could not detect address for %s record", typ)
+	}
+	if (ip.To4() != nil) != (typ == dns1cloud.RecordTypeA) {
+		return errors.Errorf("address %s does not fit %s record", ip, typ)
+	}
+
+	addr := ip.String()
+	if addr == *last {
+		return nil
+	}
+
+	if err = u.sync(ctx, typ, ip); err != nil {
+		return err
+	}
+
+	*last = addr
+	return errors.Wrap(u.store.Save(u.state), "could not save state")
+}
+
+// sync makes the record of the host point at ip
+func (u *Updater) sync(ctx context.Context, typ dns1cloud.RecordType, ip net.IP) error {
+	domain, err := u.client.GetDomain(ctx, u.domainID)
+	if err != nil {
+		return errors.Wrap(err, "could not get domain")
+	}
+
+	for _, r := range domain.LinkedRecords {
+		if r.TypeRecord != typ || !sameHost(r.HostName, u.hostName) {
+			continue
+		}
+		if net.ParseIP(r.IP).Equal(ip) {
+			return nil
+		}
+
+		r.IP = ip.String()
+		if u.ttl != 0 {
+			r.TTL = u.ttl
+		}
+		if _, err = u.client.UpdateRecord(ctx, u.domainID, r); err != nil {
+			return errors.Wrapf(err, "could not update %s record", typ)
+		}
+		return nil
+	}
+
+	_, err = u.client.AddRecord(ctx, u.domainID, dns1cloud.Record{
+		TypeRecord: typ,
+		HostName:   u.hostName,
+		IP:         ip.String(),
+		TTL:        u.ttl,
+	})
+	return errors.Wrapf(err, "could not add %s record", typ)
+}
+
+func sameHost(a, b string) bool {
+	a, b = strings.TrimSuffix(a, "."), strings.TrimSuffix(b, ".")
+	if len(a) == 0 {
+		a = "@"
+	}
+	if len(b) == 0 {
+		b = "@"
+	}
+	return strings.EqualFold(a, b)
+}
@@ -0,0 +1,184 @@
+package ddns
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/reinventer/dns1cloud"
+	"github.com/reinventer/dns1cloud/dns1cloudtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func staticSource(ip *string) Source {
+	return SourceFunc(func(context.Context) (net.IP, error) {
+		return net.ParseIP(*ip), nil
+	})
+}
+
+func TestUpdater_Update(t *testing.T) {
+	srv := dns1cloudtest.NewServer("key")
+	defer srv.Close()
+
+	d := srv.AddDomain("domain.com")
+	_, ok := srv.AddRecord(d.ID, dns1cloud.Record{TypeRecord: dns1cloud.RecordTypeA, HostName: "home", IP: "1.1.1.1", TTL: 300})
+	require.True(t, ok)
+
+	ipv4, ipv6 := "1.1.1.1", "2001:db8::1"
+	store := &FileStore{Path: filepath.Join(t.TempDir(), "state.json")}
+	u := New(srv.Client(), d.ID, "home",
+		WithIPv4Source(staticSource(&ipv4)),
+		WithIPv6Source(staticSource(&ipv6)),
+		WithTTL(600),
+		WithStateStore(store),
+	)
+
+	// the A record is up to date, the AAAA record is created
+	require.NoError(t, u.Update(context.Background()))
+	domain, _ := srv.Domain(d.ID)
+	require.Len(t, domain.LinkedRecords, 2)
+	assert.Equal(t, "1.1.1.1", domain.LinkedRecords[0].IP)
+	assert.Equal(t, uint32(300), domain.LinkedRecords[0].TTL)
+	assert.Equal(t, dns1cloud.RecordTypeAAAA, domain.LinkedRecords[1].TypeRecord)
+	assert.Equal(t, "2001:db8::1", domain.LinkedRecords[1].IP)
+
+	st, err := store.Load()
+	require.NoError(t, err)
+	assert.Equal(t, State{IPv4: "1.1.1.1", IPv6: "2001:db8::1"}, st)
+
+	// nothing is changed, the API is not called
+	requests := srv.Requests()
+	require.NoError(t, u.Update(context.Background()))
+	assert.Equal(t, requests, srv.Requests())
+
+	// the address is changed, the record is updated
+	ipv4 = "2.2.2.2"
+	require.NoError(t, u.Update(context.Background()))
+	domain, _ = srv.Domain(d.ID)
+	require.Len(t, domain.LinkedRecords, 2)
+	assert.Equal(t, "2.2.2.2", domain.LinkedRecords[0].IP)
+	assert.Equal(t, uint32(600), domain.LinkedRecords[0].TTL)
+
+	// the new updater restores state from the store
+	requests = srv.Requests()
+	u = New(srv.Client(), d.ID, "home", WithIPv4Source(staticSource(&ipv4)), WithStateStore(store))
+	require.NoError(t, u.Update(context.Background()))
+	assert.Equal(t, requests, srv.Requests())
+}
+
+func TestUpdater_Update_errors(t *testing.T) {
+	srv := dns1cloudtest.NewServer("key")
+	defer srv.Close()
+
+	d := srv.AddDomain("domain.com")
+
+	testCases := []struct {
+		name   string
+		source Source
+		err    string
+	}{
+		{
+			name: "wrong family",
+			source: SourceFunc(func(context.Context) (net.IP, error) {
+				return net.ParseIP("2001:db8::1"), nil
+			}),
+			err: "address 2001:db8::1 does not fit A record",
+		},
+		{
+			name: "source error",
+			source: SourceFunc(func(context.Context) (net.IP, error) {
+				return nil, errors.New("no route")
+			}),
+			err: "could not detect address for A record: no route",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			u := New(srv.Client(), d.ID, "home", WithIPv4Source(tc.source))
+			assert.EqualError(t, u.Update(context.Background()), tc.err)
+		})
+	}
+
+	// the failed update is retried on the next call
+	ip := "1.1.1.1"
+	u := New(srv.Client(), 100500, "home", WithIPv4Source(staticSource(&ip)))
+	assert.Error(t, u.Update(context.Background()))
+	assert.Error(t, u.Update(context.Background()))
+}
+
+func TestUpdater_RunInvalidInterval(t *testing.T) {
+	ip := "1.2.3.4"
+	u := New(nil, 1, "home", WithIPv4Source(staticSource(&ip)))
+	assert.EqualError(t, u.Run(context.Background(), 0), "interval 0s is not positive")
+}
+
+func TestHTTPSource_Detect(t *testing.T) {
+	testCases := []struct {
+		name   string
+		status int
+		body   string
+		expIP  string
+		expErr string
+	}{
+		{
+			name:   "IPv4",
+			status: http.StatusOK,
+			body:   "1.2.3.4\n",
+			expIP:  "1.2.3.4",
+		},
+		{
+			name:   "IPv6",
+			status: http.StatusOK,
+			body:   "2001:db8::1",
+			expIP:  "2001:db8::1",
+		},
+		{
+			name:   "bad status",
+			status: http.StatusBadGateway,
+			expErr: "bad response, status: 502",
+		},
+		{
+			name:   "not IP",
+			status: http.StatusOK,
+			body:   "hello",
+			expErr: `response "hello" is not IP address`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer ts.Close()
+
+			ip, err := (&HTTPSource{URL: ts.URL}).Detect(context.Background())
+			if len(tc.expErr) > 0 {
+				assert.EqualError(t, err, tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expIP, ip.String())
+		})
+	}
+}
+
+func TestHTTPSource_DetectTimeout(t *testing.T) {
+	done := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-done
+	}))
+	defer ts.Close()
+	defer close(done)
+
+	_, err := (&HTTPSource{URL: ts.URL, Timeout: 20 * time.Millisecond}).Detect(context.Background())
+	assert.EqualError(t, err, `could not do http request: Get "`+ts.URL+`": context deadline exceeded`)
+}
@@ -0,0 +1,115 @@
+package ddns
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// maxResponseSize is the maximum size of response of echo endpoint which is read
+	maxResponseSize = 256
+	// defaultTimeout is the timeout of request to echo endpoint if HTTPSource.Timeout is not set
+	defaultTimeout = 10 * time.Second
+)
+
+// Source detects current IP address
+type Source interface {
+	Detect(ctx context.Context) (net.IP, error)
+}
+
+// SourceFunc is an adapter allowing use of function as Source
+type SourceFunc func(ctx context.Context) (net.IP, error)
+
+// Detect calls f(ctx)
+func (f SourceFunc) Detect(ctx context.Context) (net.IP, error) {
+	return f(ctx)
+}
+
+// HTTPSource detects public IP address by echo endpoint responding with
+// the address of the client as plain text, e.g. https://api.ipify.org
+type HTTPSource struct {
+	URL string
+	// Client is used for requests, http.DefaultClient is used if it is nil
+	Client *http.Client
+	// Timeout limits duration of the request, 10 seconds is used if it is zero
+	Timeout time.Duration
+}
+
+// Detect implements Source
+func (s *HTTPSource) Detect(ctx context.Context) (net.IP, error) {
+	req, err := http.NewRequest(http.MethodGet, s.URL, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not make request object")
+	}
+
+	cli := s.Client
+	if cli == nil {
+		cli = http.DefaultClient
+	}
+
+	timeout := s.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	resp, err := cli.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not do http request")
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read response")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("bad response, status: %d", resp.StatusCode)
+	}
+
+	ip := net.ParseIP(strings.TrimSpace(string(body)))
+	if ip == nil {
+		return nil, errors.Errorf("response %q is not IP address", body)
+	}
+	return ip, nil
+}
+
+// InterfaceSource detects IP address as the first global unicast address of local network interface
+type InterfaceSource struct {
+	// Name is name of the interface, e.g. "eth0"
+	Name string
+	// IPv6 selects IPv6 address instead of IPv4
+	IPv6 bool
+}
+
+// Detect implements Source
+func (s *InterfaceSource) Detect(ctx context.Context) (net.IP, error) {
+	iface, err := net.InterfaceByName(s.Name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get interface %s", s.Name)
+	}
+
+	addrs, err := iface.Addrs()
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not get addresses of interface %s", s.Name)
+	}
+
+	for _, a := range addrs {
+		ipNet, ok := a.(*net.IPNet)
+		if !ok || !ipNet.IP.IsGlobalUnicast() {
+			continue
+		}
+		if (ipNet.IP.To4() == nil) == s.IPv6 {
+			return ipNet.IP, nil
+		}
+	}
+	return nil, errors.Errorf("interface %s has no suitable address", s.Name)
+}
@@ -0,0 +1,82 @@
+package ddns
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// State is the last known state of the records
+type State struct {
+	IPv4 string `json:"ipv4,omitempty"`
+	IPv6 string `json:"ipv6,omitempty"`
+}
+
+// StateStore persists state between runs
+type StateStore interface {
+	Load() (State, error)
+	Save(State) error
+}
+
+// FileStore keeps state in JSON file
+type FileStore struct {
+	Path string
+}
+
+// Load implements StateStore, missing file means empty state
+func (f *FileStore) Load() (State, error) {
+	var st State
+
+	b, err := ioutil.ReadFile(f.Path)
+	if os.IsNotExist(err) {
+		return st, nil
+	}
+	if err != nil {
+		return st, errors.Wrap(err, "could not read state file")
+	}
+
+	if err = json.Unmarshal(b, &st); err != nil {
+		return st, errors.Wrap(err, "could not parse state file")
+	}
+	return st, nil
+}
+
+// Save implements StateStore, the file is replaced atomically
+func (f *FileStore) Save(st State) error {
+	b, err := json.Marshal(st)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal state")
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(f.Path), filepath.Base(f.Path)+".tmp")
+	if err != nil {
+		return errors.Wrap(err, "could not create state file")
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.Write(b); err != nil {
+		tmp.Close()
+		return errors.Wrap(err, "could not write state file")
+	}
+	if err = tmp.Close(); err != nil {
+		return errors.Wrap(err, "could not write state file")
+	}
+	return errors.Wrap(os.Rename(tmp.Name(), f.Path), "could not replace state file")
+}
+
+// memoryStore keeps state in memory, it is used when no store is set
+type memoryStore struct {
+	st State
+}
+
+func (m *memoryStore) Load() (State, error) {
+	return m.st, nil
+}
+
+func (m *memoryStore) Save(st State) error {
+	m.st = st
+	return nil
+}
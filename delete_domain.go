@@ -0,0 +1,25 @@
+package dns1cloud
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+// DeleteDomain deletes domain by id
+func (c *DNS1Cloud) DeleteDomain(ctx context.Context, domainID uint64) (err error) {
+	ctx, sp := c.startSpan(ctx, "delete_domain", Attribute{Key: attrDomainID, Value: domainID})
+	defer func() { sp.end(err) }()
+
+	cmd := command{
+		name:     "delete_domain",
+		method:   http.MethodDelete,
+		endpoint: fmt.Sprintf("dns/%d", domainID),
+	}
+	if err = c.send(ctx, cmd, nil); err != nil {
+		return errors.Wrap(err, "could not send command delete_domain")
+	}
+	return nil
+}
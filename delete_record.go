@@ -13,12 +13,19 @@ func (c *DNS1Cloud) DeleteRecord(
 	ctx context.Context,
 	domainID uint64,
 	recordID uint64,
-) error {
+) (err error) {
+	ctx, sp := c.startSpan(ctx, "delete_record",
+		Attribute{Key: attrDomainID, Value: domainID},
+		Attribute{Key: attrRecordID, Value: recordID},
+	)
+	defer func() { sp.end(err) }()
+
 	cmd := command{
+		name:     "delete_record",
 		method:   http.MethodDelete,
 		endpoint: fmt.Sprintf("dns/%d/%d", domainID, recordID),
 	}
-	if err := c.send(ctx, cmd, nil); err != nil {
+	if err = c.send(ctx, cmd, nil); err != nil {
 		return errors.Wrap(err, "could not send command delete_record")
 	}
 	return nil
@@ -0,0 +1,60 @@
+package dns1cloudtest
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Fault describes misbehavior of the server
+type Fault struct {
+	// Method limits the fault to requests with the HTTP method, empty means any method
+	Method string
+	// PathPrefix limits the fault to requests which path starts with the prefix
+	PathPrefix string
+	// Latency delays the response
+	Latency time.Duration
+	// Status is status of the response, zero means that request is handled normally after latency
+	Status int
+	// RetryAfter is value of Retry-After header of the failed response
+	RetryAfter string
+	// Times is number of requests affected by the fault, zero means all requests
+	Times int
+}
+
+// InjectFault adds fault to the server. Faults are checked in order of injection,
+// the first matching one is applied to the request
+func (s *Server) InjectFault(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &f)
+}
+
+// ClearFaults removes all faults
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// nextFault returns the fault for the request and decrements its counter
+func (s *Server) nextFault(r *http.Request) *Fault {
+	for i, f := range s.faults {
+		if len(f.Method) > 0 && f.Method != r.Method {
+			continue
+		}
+		if !strings.HasPrefix(r.URL.Path, f.PathPrefix) {
+			continue
+		}
+
+		applied := *f
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				s.faults = append(s.faults[:i:i], s.faults[i+1:]...)
+			}
+		}
+		return &applied
+	}
+	return nil
+}
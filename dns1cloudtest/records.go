@@ -0,0 +1,125 @@
+package dns1cloudtest
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/reinventer/dns1cloud"
+)
+
+// recordParams is a union of parameters of requests for creating and updating records
+type recordParams struct {
+	DomainID     string `json:"DomainId"`
+	IP           string `json:"IP"`
+	Name         string `json:"Name"`
+	HostName     string `json:"HostName"`
+	MnemonicName string `json:"MnemonicName"`
+	Priority     string `json:"Priority"`
+	Text         string `json:"Text"`
+	Service      string `json:"Service"`
+	Proto        string `json:"Proto"`
+	Weight       string `json:"Weight"`
+	Port         string `json:"Port"`
+	Target       string `json:"Target"`
+	Flag         string `json:"Flag"`
+	Tag          string `json:"Tag"`
+	Value        string `json:"Value"`
+	TTL          string `json:"TTL"`
+}
+
+// decodeRecord decodes record from the request for endpoint dns/record{typ}
+func decodeRecord(w http.ResponseWriter, r *http.Request, typ string) (dns1cloud.Record, uint64, bool) {
+	recordType, err := dns1cloud.ParseRecordType(typ)
+	if err != nil {
+		writeError(w, http.StatusNotFound, "No HTTP resource was found that matches the request URI.")
+		return dns1cloud.Record{}, 0, false
+	}
+
+	var p recordParams
+	if err = json.NewDecoder(r.Body).Decode(&p); err != nil {
+		writeError(w, http.StatusBadRequest, "The request is invalid.")
+		return dns1cloud.Record{}, 0, false
+	}
+
+	domainID, err := strconv.ParseUint(p.DomainID, 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "DomainId is invalid")
+		return dns1cloud.Record{}, 0, false
+	}
+
+	record := dns1cloud.Record{TypeRecord: recordType}
+	if len(p.TTL) > 0 {
+		ttl, err := strconv.ParseUint(p.TTL, 10, 32)
+		if err != nil || !dns1cloud.IsValidTTL(uint32(ttl)) {
+			writeError(w, http.StatusBadRequest, "TTL is invalid")
+			return dns1cloud.Record{}, 0, false
+		}
+		record.TTL = uint32(ttl)
+	}
+
+	switch recordType {
+	case dns1cloud.RecordTypeA, dns1cloud.RecordTypeAAAA:
+		ip := net.ParseIP(p.IP)
+		if ip == nil || (ip.To4() != nil) != (recordType == dns1cloud.RecordTypeA) {
+			writeError(w, http.StatusBadRequest, "IP is invalid")
+			return dns1cloud.Record{}, 0, false
+		}
+		record.IP, record.HostName = p.IP, p.Name
+	case dns1cloud.RecordTypeCNAME:
+		record.HostName, record.MnemonicName = p.Name, p.MnemonicName
+	case dns1cloud.RecordTypeMX:
+		record.HostName, record.Priority = p.HostName, p.Priority
+	case dns1cloud.RecordTypeNS:
+		record.HostName, record.ExtHostName = p.HostName, p.Name
+	case dns1cloud.RecordTypeTXT:
+		record.HostName, record.Text = p.Name, p.Text
+	case dns1cloud.RecordTypeSRV:
+		record.HostName, record.Service, record.Proto = p.Name, p.Service, p.Proto
+		record.Priority, record.Weight, record.Port, record.Target = p.Priority, p.Weight, p.Port, p.Target
+	case dns1cloud.RecordTypeCAA:
+		record.HostName, record.Flag, record.Tag, record.Value = p.Name, p.Flag, p.Tag, p.Value
+	case dns1cloud.RecordTypePTR:
+		record.HostName, record.Target = p.Name, p.Target
+	}
+
+	return record, domainID, true
+}
+
+// canonicalDescription returns the record in master file format
+func canonicalDescription(domain string, r dns1cloud.Record) string {
+	var owner, data string
+	switch r.TypeRecord {
+	case dns1cloud.RecordTypeA, dns1cloud.RecordTypeAAAA:
+		owner, data = r.HostName, r.IP
+	case dns1cloud.RecordTypeCNAME:
+		owner, data = r.MnemonicName, target(r.HostName, domain)
+	case dns1cloud.RecordTypeMX:
+		owner, data = "@", r.Priority+" "+target(r.HostName, domain)
+	case dns1cloud.RecordTypeNS:
+		owner, data = r.ExtHostName, target(r.HostName, domain)
+	case dns1cloud.RecordTypeTXT:
+		owner, data = r.HostName, strconv.Quote(r.Text)
+	case dns1cloud.RecordTypeSRV:
+		owner = "_" + strings.Trim(r.Service, "._") + "._" + strings.Trim(r.Proto, "._") + "." + dns1cloud.FQDN(r.HostName, domain)
+		data = strings.Join([]string{r.Priority, r.Weight, r.Port, target(r.Target, domain)}, " ")
+	case dns1cloud.RecordTypeCAA:
+		owner, data = r.HostName, r.Flag+" "+r.Tag+" "+strconv.Quote(r.Value)
+	case dns1cloud.RecordTypePTR:
+		owner, data = r.HostName, target(r.Target, domain)
+	}
+
+	return dns1cloud.FQDN(owner, domain) + " " + strconv.FormatUint(uint64(r.TTL), 10) + " IN " + r.TypeRecord.String() + " " + data
+}
+
+// target returns fully qualified target host name with trailing dot.
+// API keeps fully qualified targets without trailing dot, so any name with a dot
+// is treated as absolute
+func target(name, domain string) string {
+	if !strings.HasSuffix(name, ".") && strings.Contains(name, ".") {
+		return dns1cloud.FQDN(name+".", domain)
+	}
+	return dns1cloud.FQDN(name, domain)
+}
@@ -0,0 +1,353 @@
+// Package dns1cloudtest provides in-memory fake of API of 1Cloud's DNS hosting
+// for testing code built on top of dns1cloud package
+package dns1cloudtest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/reinventer/dns1cloud"
+)
+
+// Server is a fake API server keeping domains and records in memory
+type Server struct {
+	// URL is base URL of the server, it is passed to dns1cloud.WithApiHost
+	URL string
+
+	apiKey string
+	srv    *httptest.Server
+
+	mu           sync.Mutex
+	domains      map[uint64]*dns1cloud.Domain
+	recordDomain map[uint64]uint64
+	nextDomainID uint64
+	nextRecordID uint64
+	faults       []*Fault
+	requests     int
+	now          func() time.Time
+}
+
+// NewServer starts and returns new Server accepting requests with apiKey only
+func NewServer(apiKey string) *Server {
+	s := &Server{
+		apiKey:       apiKey,
+		domains:      make(map[uint64]*dns1cloud.Domain),
+		recordDomain: make(map[uint64]uint64),
+		nextDomainID: 1,
+		nextRecordID: 1,
+		now:          time.Now,
+	}
+	s.srv = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.srv.URL
+	return s
+}
+
+// Close shuts down the server
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// Client returns new client of the server
+func (s *Server) Client(opts ...dns1cloud.OptFunc) *dns1cloud.DNS1Cloud {
+	opts = append([]dns1cloud.OptFunc{dns1cloud.WithApiHost(s.URL)}, opts...)
+	return dns1cloud.New(s.apiKey, opts...)
+}
+
+// Requests returns number of requests received by the server
+func (s *Server) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+// AddDomain adds domain to the storage bypassing API
+func (s *Server) AddDomain(name string) dns1cloud.Domain {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return *s.addDomain(name)
+}
+
+// AddRecord adds record to the domain bypassing API
+func (s *Server) AddRecord(domainID uint64, record dns1cloud.Record) (dns1cloud.Record, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d, ok := s.domains[domainID]
+	if !ok {
+		return dns1cloud.Record{}, false
+	}
+	return s.addRecord(d, record), true
+}
+
+// Domain returns copy of the domain with its records
+func (s *Server) Domain(domainID uint64) (dns1cloud.Domain, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d, ok := s.domains[domainID]
+	if !ok {
+		return dns1cloud.Domain{}, false
+	}
+	return copyDomain(d), true
+}
+
+// Domains returns copies of all domains ordered by ID
+func (s *Server) Domains() []dns1cloud.Domain {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.list()
+}
+
+func (s *Server) addDomain(name string) *dns1cloud.Domain {
+	d := &dns1cloud.Domain{
+		ID:            s.nextDomainID,
+		Name:          name,
+		TechName:      strings.Replace(name, ".", "_", -1),
+		State:         dns1cloud.StateActive,
+		DateCreate:    dns1cloud.DateTime{Time: s.now().UTC()},
+		LinkedRecords: []dns1cloud.Record{},
+	}
+	s.nextDomainID++
+	s.domains[d.ID] = d
+	return d
+}
+
+func (s *Server) addRecord(d *dns1cloud.Domain, r dns1cloud.Record) dns1cloud.Record {
+	r.ID = s.nextRecordID
+	r.State = dns1cloud.StateActive
+	r.DateCreate = dns1cloud.DateTime{Time: s.now().UTC()}
+	r.CanonicalDescription = canonicalDescription(d.Name, r)
+	s.nextRecordID++
+
+	d.LinkedRecords = append(d.LinkedRecords, r)
+	s.recordDomain[r.ID] = d.ID
+	return r
+}
+
+func (s *Server) list() []dns1cloud.Domain {
+	ids := make([]uint64, 0, len(s.domains))
+	for id := range s.domains {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	domains := make([]dns1cloud.Domain, 0, len(ids))
+	for _, id := range ids {
+		domains = append(domains, copyDomain(s.domains[id]))
+	}
+	return domains
+}
+
+func copyDomain(d *dns1cloud.Domain) dns1cloud.Domain {
+	c := *d
+	c.LinkedRecords = append([]dns1cloud.Record{}, d.LinkedRecords...)
+	return c
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests++
+	fault := s.nextFault(r)
+	s.mu.Unlock()
+
+	if fault != nil {
+		if fault.Latency > 0 {
+			select {
+			case <-time.After(fault.Latency):
+			case <-r.Context().Done():
+				return
+			}
+		}
+		if fault.Status != 0 {
+			if len(fault.RetryAfter) > 0 {
+				w.Header().Set("Retry-After", fault.RetryAfter)
+			}
+			writeError(w, fault.Status, http.StatusText(fault.Status))
+			return
+		}
+	}
+
+	if r.Header.Get("Authorization") != "Bearer "+s.apiKey {
+		writeError(w, http.StatusUnauthorized, "Authorization has been denied for this request.")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.route(w, r)
+}
+
+func (s *Server) route(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if parts[0] != "dns" {
+		writeError(w, http.StatusNotFound, "No HTTP resource was found that matches the request URI.")
+		return
+	}
+
+	switch {
+	case len(parts) == 1 && r.Method == http.MethodGet:
+		writeJSON(w, s.list())
+	case len(parts) == 1 && r.Method == http.MethodPost:
+		s.handleAddDomain(w, r)
+	case len(parts) == 2 && strings.HasPrefix(parts[1], "record") && r.Method == http.MethodPost:
+		s.handleAddRecord(w, r, strings.TrimPrefix(parts[1], "record"))
+	case len(parts) == 2 && r.Method == http.MethodGet:
+		s.handleGetDomain(w, parts[1])
+	case len(parts) == 2 && r.Method == http.MethodDelete:
+		s.handleDeleteDomain(w, parts[1])
+	case len(parts) == 3 && parts[1] == "record" && r.Method == http.MethodGet:
+		s.handleGetRecord(w, parts[2])
+	case len(parts) == 3 && strings.HasPrefix(parts[1], "record") && r.Method == http.MethodPut:
+		s.handleUpdateRecord(w, r, strings.TrimPrefix(parts[1], "record"), parts[2])
+	case len(parts) == 3 && r.Method == http.MethodDelete:
+		s.handleDeleteRecord(w, parts[1], parts[2])
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "The requested resource does not support http method '"+r.Method+"'.")
+	}
+}
+
+func (s *Server) handleAddDomain(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Name string `json:"Name"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil || len(params.Name) == 0 {
+		writeError(w, http.StatusBadRequest, "Name is required")
+		return
+	}
+
+	for _, d := range s.domains {
+		if strings.EqualFold(d.Name, params.Name) {
+			writeError(w, http.StatusConflict, "Domain "+params.Name+" already exists")
+			return
+		}
+	}
+
+	writeJSON(w, copyDomain(s.addDomain(params.Name)))
+}
+
+func (s *Server) handleGetDomain(w http.ResponseWriter, id string) {
+	d, ok := s.domainByID(w, id)
+	if ok {
+		writeJSON(w, copyDomain(d))
+	}
+}
+
+func (s *Server) handleDeleteDomain(w http.ResponseWriter, id string) {
+	d, ok := s.domainByID(w, id)
+	if !ok {
+		return
+	}
+	for _, r := range d.LinkedRecords {
+		delete(s.recordDomain, r.ID)
+	}
+	delete(s.domains, d.ID)
+}
+
+func (s *Server) handleGetRecord(w http.ResponseWriter, id string) {
+	d, i, ok := s.recordByID(w, id)
+	if ok {
+		writeJSON(w, d.LinkedRecords[i])
+	}
+}
+
+func (s *Server) handleAddRecord(w http.ResponseWriter, r *http.Request, typ string) {
+	record, domainID, ok := decodeRecord(w, r, typ)
+	if !ok {
+		return
+	}
+
+	d, ok := s.domains[domainID]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Domain is not found")
+		return
+	}
+
+	writeJSON(w, s.addRecord(d, record))
+}
+
+func (s *Server) handleUpdateRecord(w http.ResponseWriter, r *http.Request, typ, id string) {
+	record, domainID, ok := decodeRecord(w, r, typ)
+	if !ok {
+		return
+	}
+
+	d, i, ok := s.recordByID(w, id)
+	if !ok {
+		return
+	}
+	cur := d.LinkedRecords[i]
+	if d.ID != domainID || cur.TypeRecord != record.TypeRecord {
+		writeError(w, http.StatusBadRequest, "Record does not match domain or type")
+		return
+	}
+
+	record.ID = cur.ID
+	record.State = cur.State
+	record.DateCreate = cur.DateCreate
+	record.CanonicalDescription = canonicalDescription(d.Name, record)
+	d.LinkedRecords[i] = record
+
+	writeJSON(w, record)
+}
+
+func (s *Server) handleDeleteRecord(w http.ResponseWriter, domainID, recordID string) {
+	d, i, ok := s.recordByID(w, recordID)
+	if !ok {
+		return
+	}
+	if strconv.FormatUint(d.ID, 10) != domainID {
+		writeError(w, http.StatusNotFound, "Record is not found")
+		return
+	}
+
+	delete(s.recordDomain, d.LinkedRecords[i].ID)
+	d.LinkedRecords = append(d.LinkedRecords[:i], d.LinkedRecords[i+1:]...)
+}
+
+func (s *Server) domainByID(w http.ResponseWriter, id string) (*dns1cloud.Domain, bool) {
+	domainID, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "The request is invalid.")
+		return nil, false
+	}
+	d, ok := s.domains[domainID]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Domain is not found")
+		return nil, false
+	}
+	return d, true
+}
+
+func (s *Server) recordByID(w http.ResponseWriter, id string) (*dns1cloud.Domain, int, bool) {
+	recordID, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "The request is invalid.")
+		return nil, 0, false
+	}
+	if d, ok := s.domains[s.recordDomain[recordID]]; ok {
+		for i, r := range d.LinkedRecords {
+			if r.ID == recordID {
+				return d, i, true
+			}
+		}
+	}
+	writeError(w, http.StatusNotFound, "Record is not found")
+	return nil, 0, false
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"Message": msg})
+}
@@ -0,0 +1,184 @@
+package dns1cloudtest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/reinventer/dns1cloud"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_Domains(t *testing.T) {
+	s := NewServer("apiKey")
+	defer s.Close()
+
+	c := s.Client()
+	ctx := context.Background()
+
+	domain, err := c.AddDomain(ctx, "domain.com")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), domain.ID)
+	assert.Equal(t, "domain_com", domain.TechName)
+	assert.False(t, domain.DateCreate.IsZero())
+
+	_, err = c.AddDomain(ctx, "domain.com")
+	assert.True(t, dns1cloud.IsConflict(err))
+
+	domains, err := c.List(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []dns1cloud.Domain{domain}, domains)
+
+	assert.NoError(t, c.DeleteDomain(ctx, domain.ID))
+
+	_, err = c.GetDomain(ctx, domain.ID)
+	assert.True(t, dns1cloud.IsNotFound(err))
+}
+
+func TestServer_Records(t *testing.T) {
+	s := NewServer("apiKey")
+	defer s.Close()
+
+	c := s.Client()
+	ctx := context.Background()
+	domain := s.AddDomain("domain.com")
+
+	testCases := []struct {
+		record       dns1cloud.Record
+		expCanonical string
+	}{
+		{
+			record:       dns1cloud.Record{TypeRecord: dns1cloud.RecordTypeA, HostName: "www", IP: "1.1.1.1", TTL: 300},
+			expCanonical: "www.domain.com. 300 IN A 1.1.1.1",
+		},
+		{
+			record:       dns1cloud.Record{TypeRecord: dns1cloud.RecordTypeAAAA, HostName: "@", IP: "2001:db8::68", TTL: 300},
+			expCanonical: "domain.com. 300 IN AAAA 2001:db8::68",
+		},
+		{
+			record:       dns1cloud.Record{TypeRecord: dns1cloud.RecordTypeCNAME, HostName: "www", MnemonicName: "ftp", TTL: 300},
+			expCanonical: "ftp.domain.com. 300 IN CNAME www.domain.com.",
+		},
+		{
+			record:       dns1cloud.Record{TypeRecord: dns1cloud.RecordTypeMX, HostName: "mail.test.com", Priority: "10", TTL: 300},
+			expCanonical: "domain.com. 300 IN MX 10 mail.test.com.",
+		},
+		{
+			record:       dns1cloud.Record{TypeRecord: dns1cloud.RecordTypeNS, HostName: "ns1.test.com", ExtHostName: "sub", TTL: 300},
+			expCanonical: "sub.domain.com. 300 IN NS ns1.test.com.",
+		},
+		{
+			record:       dns1cloud.Record{TypeRecord: dns1cloud.RecordTypeTXT, HostName: "@", Text: "hello", TTL: 300},
+			expCanonical: `domain.com. 300 IN TXT "hello"`,
+		},
+		{
+			record: dns1cloud.Record{
+				TypeRecord: dns1cloud.RecordTypeSRV, HostName: "@", Service: "sip", Proto: "tcp",
+				Priority: "10", Weight: "5", Port: "5060", Target: "sip", TTL: 300,
+			},
+			expCanonical: "_sip._tcp.domain.com. 300 IN SRV 10 5 5060 sip.domain.com.",
+		},
+		{
+			record:       dns1cloud.Record{TypeRecord: dns1cloud.RecordTypeCAA, HostName: "@", Flag: "0", Tag: "issue", Value: "ca.test", TTL: 300},
+			expCanonical: `domain.com. 300 IN CAA 0 issue "ca.test"`,
+		},
+		{
+			record:       dns1cloud.Record{TypeRecord: dns1cloud.RecordTypePTR, HostName: "10", Target: "host.test.com.", TTL: 300},
+			expCanonical: "10.domain.com. 300 IN PTR host.test.com.",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.record.TypeRecord.String(), func(t *testing.T) {
+			record, err := c.AddRecord(ctx, domain.ID, tc.record)
+			assert.NoError(t, err)
+			assert.NotZero(t, record.ID)
+			assert.Equal(t, dns1cloud.StateActive, record.State)
+			assert.Equal(t, tc.expCanonical, record.CanonicalDescription)
+
+			got, err := c.GetRecord(ctx, record.ID)
+			assert.NoError(t, err)
+			assert.Equal(t, record, got)
+
+			tc.record.ID = record.ID
+			tc.record.TTL = 600
+			updated, err := c.UpdateRecord(ctx, domain.ID, tc.record)
+			assert.NoError(t, err)
+			assert.Equal(t, uint32(600), updated.TTL)
+			assert.Equal(t, record.DateCreate, updated.DateCreate)
+		})
+	}
+
+	d, err := c.GetDomain(ctx, domain.ID)
+	assert.NoError(t, err)
+	assert.Len(t, d.LinkedRecords, len(testCases))
+
+	first := d.LinkedRecords[0]
+	assert.NoError(t, c.DeleteRecord(ctx, domain.ID, first.ID))
+	_, err = c.GetRecord(ctx, first.ID)
+	assert.True(t, dns1cloud.IsNotFound(err))
+
+	stored, ok := s.Domain(domain.ID)
+	assert.True(t, ok)
+	assert.Len(t, stored.LinkedRecords, len(testCases)-1)
+}
+
+func TestServer_Validation(t *testing.T) {
+	s := NewServer("apiKey")
+	defer s.Close()
+
+	c := s.Client()
+	ctx := context.Background()
+	domain := s.AddDomain("domain.com")
+	record, _ := s.AddRecord(domain.ID, dns1cloud.Record{TypeRecord: dns1cloud.RecordTypeA, HostName: "@", IP: "1.1.1.1"})
+
+	_, err := c.AddRecord(ctx, 100, dns1cloud.Record{TypeRecord: dns1cloud.RecordTypeA, HostName: "@", IP: "1.1.1.1"})
+	assert.True(t, dns1cloud.IsNotFound(err))
+
+	// invalid records are rejected by the client before they reach the server
+	requests := s.Requests()
+	_, err = c.AddRecord(ctx, domain.ID, dns1cloud.Record{TypeRecord: dns1cloud.RecordTypeAAAA, HostName: "@", IP: "1.1.1.1"})
+	assert.True(t, dns1cloud.IsValidationError(err))
+
+	_, err = c.UpdateRecord(ctx, domain.ID, dns1cloud.Record{ID: record.ID, TypeRecord: dns1cloud.RecordTypeTXT, HostName: "@"})
+	assert.True(t, dns1cloud.IsValidationError(err))
+	assert.Equal(t, requests, s.Requests())
+
+	assert.True(t, dns1cloud.IsNotFound(c.DeleteRecord(ctx, domain.ID+1, record.ID)))
+
+	_, err = s.Client().GetRecord(ctx, 100)
+	assert.True(t, dns1cloud.IsNotFound(err))
+
+	_, err = dns1cloud.New("wrongKey", dns1cloud.WithApiHost(s.URL)).List(ctx)
+	assert.True(t, dns1cloud.IsUnauthorized(err))
+}
+
+func TestServer_Faults(t *testing.T) {
+	s := NewServer("apiKey")
+	defer s.Close()
+
+	ctx := context.Background()
+	s.AddDomain("domain.com")
+
+	s.InjectFault(Fault{Method: http.MethodGet, Status: http.StatusServiceUnavailable, Times: 2})
+	c := s.Client(dns1cloud.WithRetryPolicy(dns1cloud.RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond}))
+
+	domains, err := c.List(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, domains, 1)
+	assert.Equal(t, 3, s.Requests())
+
+	s.InjectFault(Fault{PathPrefix: "/dns/record", Status: http.StatusTooManyRequests, RetryAfter: "0"})
+	_, err = c.GetRecord(ctx, 1)
+	assert.True(t, dns1cloud.IsRateLimited(err))
+	assert.Equal(t, 6, s.Requests())
+
+	s.ClearFaults()
+	s.InjectFault(Fault{Latency: 50 * time.Millisecond})
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err = s.Client().List(ctx)
+	assert.Error(t, err)
+}
@@ -0,0 +1,152 @@
+package dns1cloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// APIError is returned when API responds with non-successful status
+type APIError struct {
+	// StatusCode is HTTP status of the response
+	StatusCode int
+	// Body is raw body of the response
+	Body string
+	// Message is the error message parsed from the body, if any
+	Message string
+	// Method is HTTP method of the command
+	Method string
+	// Endpoint is endpoint of the command
+	Endpoint string
+
+	retryAfter time.Duration
+}
+
+// Error implements error interface
+func (e *APIError) Error() string {
+	return fmt.Sprintf("bad response, status: %d, body: '%s'", e.StatusCode, e.Body)
+}
+
+func newAPIError(cmd command, statusCode int, body []byte) *APIError {
+	e := &APIError{
+		StatusCode: statusCode,
+		Body:       string(body),
+		Method:     cmd.method,
+		Endpoint:   cmd.endpoint,
+	}
+
+	var msg struct {
+		Message string `json:"Message"`
+	}
+	if err := json.Unmarshal(body, &msg); err == nil {
+		e.Message = msg.Message
+	}
+
+	return e
+}
+
+// AsAPIError returns APIError from the chain of wrapped errors
+func AsAPIError(err error) (*APIError, bool) {
+	e, ok := errors.Cause(err).(*APIError)
+	return e, ok
+}
+
+// NotFoundError is returned by lookup helpers when nothing is matched
+type NotFoundError struct {
+	// Kind is kind of the object: domain, zone or record
+	Kind string
+	// Name is the name which is looked up
+	Name string
+}
+
+// Error implements error interface
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("%s %q is not found", e.Kind, e.Name)
+}
+
+// IsNotFound reports whether err is an API error with status 404 or NotFoundError
+func IsNotFound(err error) bool {
+	if _, ok := errors.Cause(err).(*NotFoundError); ok {
+		return true
+	}
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsUnauthorized reports whether err is an API error with status 401
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized)
+}
+
+// IsForbidden reports whether err is an API error with status 403
+func IsForbidden(err error) bool {
+	return hasStatus(err, http.StatusForbidden)
+}
+
+// IsConflict reports whether err is an API error with status 409
+func IsConflict(err error) bool {
+	return hasStatus(err, http.StatusConflict)
+}
+
+// IsBadRequest reports whether err is an API error with status 400
+func IsBadRequest(err error) bool {
+	return hasStatus(err, http.StatusBadRequest)
+}
+
+// IsRateLimited reports whether err is an API error with status 429
+func IsRateLimited(err error) bool {
+	return hasStatus(err, http.StatusTooManyRequests)
+}
+
+// IsServerError reports whether err is an API error with status 5xx
+func IsServerError(err error) bool {
+	e, ok := AsAPIError(err)
+	return ok && e.StatusCode >= http.StatusInternalServerError
+}
+
+func hasStatus(err error, status int) bool {
+	e, ok := AsAPIError(err)
+	return ok && e.StatusCode == status
+}
+
+// UnknownRecordTypeError is returned when record of unsupported type is passed to AddRecord or UpdateRecord
+type UnknownRecordTypeError struct {
+	// Type is type of the record
+	Type RecordType
+	// Raw is the original name of the type, if the record was received from API
+	Raw string
+}
+
+// Error implements error interface
+func (e *UnknownRecordTypeError) Error() string {
+	if len(e.Raw) > 0 {
+		return fmt.Sprintf("unknown record type: %q", e.Raw)
+	}
+	return fmt.Sprintf("unknown record type: %d", e.Type)
+}
+
+// IsUnknownRecordType reports whether err is caused by unsupported record type
+func IsUnknownRecordType(err error) bool {
+	_, ok := errors.Cause(err).(*UnknownRecordTypeError)
+	return ok
+}
+
+// DomainError is an error of operation on a single domain which does not abort the whole operation
+type DomainError struct {
+	// Domain is the domain, only ID and Name may be set
+	Domain Domain
+	// Err is the original error
+	Err error
+}
+
+// Error implements error interface
+func (e *DomainError) Error() string {
+	return fmt.Sprintf("domain %q (%d): %s", e.Domain.Name, e.Domain.ID, e.Err)
+}
+
+// Cause returns the original error, so helpers like IsNotFound work through DomainError
+func (e *DomainError) Cause() error {
+	return e.Err
+}
@@ -0,0 +1,111 @@
+package dns1cloud
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIError(t *testing.T) {
+	testCases := []struct {
+		name           string
+		responseStatus int
+		responseJSON   string
+		expMessage     string
+		check          func(error) bool
+	}{
+		{
+			name:           "not found",
+			responseStatus: http.StatusNotFound,
+			responseJSON:   `{"Message": "record not found"}`,
+			expMessage:     "record not found",
+			check:          IsNotFound,
+		},
+		{
+			name:           "unauthorized",
+			responseStatus: http.StatusUnauthorized,
+			responseJSON:   `{"Message": "Authorization has been denied for this request."}`,
+			expMessage:     "Authorization has been denied for this request.",
+			check:          IsUnauthorized,
+		},
+		{
+			name:           "forbidden",
+			responseStatus: http.StatusForbidden,
+			check:          IsForbidden,
+		},
+		{
+			name:           "conflict",
+			responseStatus: http.StatusConflict,
+			responseJSON:   `{"Message": "already exists"}`,
+			expMessage:     "already exists",
+			check:          IsConflict,
+		},
+		{
+			name:           "bad request",
+			responseStatus: http.StatusBadRequest,
+			responseJSON:   "not json",
+			check:          IsBadRequest,
+		},
+		{
+			name:           "rate limited",
+			responseStatus: http.StatusTooManyRequests,
+			check:          IsRateLimited,
+		},
+		{
+			name:           "server error",
+			responseStatus: http.StatusBadGateway,
+			check:          IsServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.responseStatus)
+				w.Write([]byte(tc.responseJSON))
+			}))
+			defer s.Close()
+
+			c := New("apiKey", WithApiHost(s.URL))
+
+			_, err := c.GetRecord(context.Background(), 124)
+			assert.Error(t, err)
+			assert.True(t, tc.check(err))
+
+			apiErr, ok := AsAPIError(err)
+			if assert.True(t, ok) {
+				assert.Equal(t, tc.responseStatus, apiErr.StatusCode)
+				assert.Equal(t, tc.responseJSON, apiErr.Body)
+				assert.Equal(t, tc.expMessage, apiErr.Message)
+				assert.Equal(t, http.MethodGet, apiErr.Method)
+				assert.Equal(t, "dns/record/124", apiErr.Endpoint)
+			}
+		})
+	}
+}
+
+func TestAPIError_NotAPIError(t *testing.T) {
+	err := errors.Wrap(errors.New("connection refused"), "could not do http request")
+
+	_, ok := AsAPIError(err)
+	assert.False(t, ok)
+	assert.False(t, IsNotFound(err))
+	assert.False(t, IsServerError(err))
+	assert.False(t, IsNotFound(nil))
+}
+
+func TestIsUnknownRecordType(t *testing.T) {
+	c := New("apiKey")
+
+	_, err := c.AddRecord(context.Background(), 123, Record{TypeRecord: RecordTypeUnknown, RawTypeRecord: "NAPTR"})
+	assert.True(t, IsUnknownRecordType(err))
+
+	_, err = c.UpdateRecord(context.Background(), 123, Record{ID: 1, TypeRecord: 100})
+	assert.True(t, IsUnknownRecordType(err))
+
+	assert.False(t, IsUnknownRecordType(errors.New("unknown record type")))
+}
@@ -0,0 +1,16 @@
+package dns1cloud
+
+import "time"
+
+// SetCacheClock sets function returning current time of the caching client
+func SetCacheClock(c *CachingClient, now func() time.Time) {
+	c.now = now
+}
+
+// SetRollbackTimeout sets limit of time spent on reverting changes of failed ChangeSet
+// and returns function restoring the previous one
+func SetRollbackTimeout(d time.Duration) func() {
+	prev := rollbackTimeout
+	rollbackTimeout = d
+	return func() { rollbackTimeout = prev }
+}
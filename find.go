@@ -0,0 +1,137 @@
+package dns1cloud
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// FindDomainByName returns domain by its name.
+// Names are compared case-insensitively, trailing dot is ignored
+func (c *DNS1Cloud) FindDomainByName(ctx context.Context, name string) (Domain, error) {
+	domains, err := c.List(ctx)
+	if err != nil {
+		return Domain{}, errors.Wrap(err, "could not find domain")
+	}
+
+	name = normalizeName(name)
+	for _, d := range domains {
+		if normalizeName(d.Name) == name {
+			return d, nil
+		}
+	}
+	return Domain{}, &NotFoundError{Kind: "domain", Name: name}
+}
+
+// FindZoneForFQDN returns domain with the longest name which fqdn belongs to,
+// e.g. "sub.domain.com" for "www.sub.domain.com." if both "domain.com" and "sub.domain.com" exist
+func (c *DNS1Cloud) FindZoneForFQDN(ctx context.Context, fqdn string) (Domain, error) {
+	domains, err := c.List(ctx)
+	if err != nil {
+		return Domain{}, errors.Wrap(err, "could not find zone")
+	}
+	return zoneForFQDN(domains, fqdn)
+}
+
+// zoneForFQDN returns domain of the list with the longest name which fqdn belongs to
+func zoneForFQDN(domains []Domain, fqdn string) (Domain, error) {
+	name := normalizeName(fqdn)
+
+	var (
+		zone    Domain
+		zoneLen = -1
+	)
+	for _, d := range domains {
+		n := normalizeName(d.Name)
+		if (name == n || strings.HasSuffix(name, "."+n)) && len(n) > zoneLen {
+			zone, zoneLen = d, len(n)
+		}
+	}
+	if zoneLen < 0 {
+		return Domain{}, &NotFoundError{Kind: "zone", Name: name}
+	}
+	return zone, nil
+}
+
+// RecordFilter reports whether record matches the filter.
+// Origin is name of the domain of the record, names are resolved against it
+type RecordFilter func(r Record, origin string) bool
+
+// FilterType matches records of any of types
+func FilterType(types ...RecordType) RecordFilter {
+	return func(r Record, _ string) bool {
+		for _, t := range types {
+			if r.TypeRecord == t {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// FilterHostName matches records by name of the record.
+// Name is the host name for most types, the alias for CNAME, the subdomain for NS
+// and "_service._proto.name" for SRV. Name may be relative to the domain or fully qualified,
+// "@" and empty name match the domain itself
+func FilterHostName(name string) RecordFilter {
+	return func(r Record, origin string) bool {
+		return keyOf(r, origin).name == RelativeName(name, origin)
+	}
+}
+
+// FilterValue matches records by value: the IP for A and AAAA, the target for
+// CNAME, MX, NS, SRV and PTR, the text for TXT and the value for CAA.
+// Target may be relative to the domain or fully qualified
+func FilterValue(value string) RecordFilter {
+	return func(r Record, origin string) bool {
+		switch r.TypeRecord {
+		case RecordTypeTXT:
+			return r.Text == value
+		case RecordTypeCAA:
+			return r.Value == value
+		case RecordTypeA, RecordTypeAAAA:
+			return strings.EqualFold(r.IP, value)
+		}
+		return keyOf(r, origin).value == RelativeName(value, origin)
+	}
+}
+
+// FilterTTL matches records which TTL satisfies predicate
+func FilterTTL(pred func(ttl uint32) bool) RecordFilter {
+	return func(r Record, _ string) bool {
+		return pred(r.TTL)
+	}
+}
+
+// AllOf matches records matching all filters
+func AllOf(filters ...RecordFilter) RecordFilter {
+	return func(r Record, origin string) bool {
+		for _, f := range filters {
+			if !f(r, origin) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// FindRecords returns records of domain matching filter, nil filter matches all records.
+// NotFoundError is returned if there are no matching records
+func (c *DNS1Cloud) FindRecords(ctx context.Context, domainID uint64, filter RecordFilter) ([]Record, error) {
+	domain, err := c.GetDomain(ctx, domainID)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not find records")
+	}
+
+	var records []Record
+	for _, r := range domain.LinkedRecords {
+		if filter == nil || filter(r, domain.Name) {
+			records = append(records, r)
+		}
+	}
+	if len(records) == 0 {
+		return nil, &NotFoundError{Kind: "matching record in domain", Name: domain.Name}
+	}
+	return records, nil
+}
@@ -0,0 +1,198 @@
+package dns1cloud_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/reinventer/dns1cloud"
+	"github.com/stretchr/testify/assert"
+)
+
+// findRecords are records of domain with IDs 1..6
+var findRecords = []dns1cloud.Record{
+	{TypeRecord: dns1cloud.RecordTypeA, IP: "1.1.1.1", HostName: "@", TTL: 3600},
+	{TypeRecord: dns1cloud.RecordTypeA, IP: "1.1.1.2", HostName: "www", TTL: 600},
+	{TypeRecord: dns1cloud.RecordTypeAAAA, IP: "2001:db8::68", HostName: "www", TTL: 600},
+	{TypeRecord: dns1cloud.RecordTypeCNAME, HostName: "www.domain.com", MnemonicName: "ftp", TTL: 300},
+	{TypeRecord: dns1cloud.RecordTypeTXT, HostName: "www", Text: "hello", TTL: 300},
+	{TypeRecord: dns1cloud.RecordTypeSRV, HostName: "@", Service: "_sip.", Proto: "tcp", Target: "sip.domain.com.", TTL: 300},
+}
+
+func TestDNS1Cloud_FindDomainByName(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	srv.AddDomain("sub.domain.com")
+	srv.AddDomain("Other.com")
+
+	c := srv.Client()
+
+	testCases := []struct {
+		name         string
+		expID        uint64
+		expErrString string
+	}{
+		{name: "domain.com", expID: 1},
+		{name: "SUB.domain.com.", expID: 2},
+		{name: "other.com", expID: 3},
+		{name: "www.domain.com", expErrString: `domain "www.domain.com" is not found`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			domain, err := c.FindDomainByName(context.Background(), tc.name)
+			if len(tc.expErrString) > 0 {
+				assert.EqualError(t, err, tc.expErrString)
+				assert.True(t, dns1cloud.IsNotFound(err))
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expID, domain.ID)
+		})
+	}
+}
+
+func TestDNS1Cloud_FindZoneForFQDN(t *testing.T) {
+	srv := newTestServer()
+	defer srv.Close()
+	srv.AddDomain("sub.domain.com")
+	srv.AddDomain("Other.com")
+
+	c := srv.Client()
+
+	testCases := []struct {
+		fqdn         string
+		expID        uint64
+		expErrString string
+	}{
+		{fqdn: "domain.com.", expID: 1},
+		{fqdn: "www.domain.com.", expID: 1},
+		{fqdn: "_acme-challenge.www.SUB.domain.com.", expID: 2},
+		{fqdn: "sub.domain.com", expID: 2},
+		{fqdn: "mail.other.com", expID: 3},
+		{fqdn: "notdomain.com.", expErrString: `zone "notdomain.com" is not found`},
+		{fqdn: "com.", expErrString: `zone "com" is not found`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.fqdn, func(t *testing.T) {
+			domain, err := c.FindZoneForFQDN(context.Background(), tc.fqdn)
+			if len(tc.expErrString) > 0 {
+				assert.EqualError(t, err, tc.expErrString)
+				assert.True(t, dns1cloud.IsNotFound(err))
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expID, domain.ID)
+		})
+	}
+}
+
+func TestDNS1Cloud_FindRecords(t *testing.T) {
+	srv := newTestServer(findRecords...)
+	defer srv.Close()
+
+	c := srv.Client()
+
+	testCases := []struct {
+		name         string
+		domainID     uint64
+		filter       dns1cloud.RecordFilter
+		expIDs       []uint64
+		expErrString string
+	}{
+		{
+			name:     "all",
+			domainID: testDomainID,
+			expIDs:   []uint64{1, 2, 3, 4, 5, 6},
+		},
+		{
+			name:     "by type and host name",
+			domainID: testDomainID,
+			filter:   dns1cloud.AllOf(dns1cloud.FilterType(dns1cloud.RecordTypeA, dns1cloud.RecordTypeAAAA), dns1cloud.FilterHostName("WWW.")),
+			expIDs:   []uint64{2, 3},
+		},
+		{
+			name:     "fully qualified host name",
+			domainID: testDomainID,
+			filter:   dns1cloud.FilterHostName("www.Domain.com."),
+			expIDs:   []uint64{2, 3, 5},
+		},
+		{
+			name:     "fully qualified apex",
+			domainID: testDomainID,
+			filter:   dns1cloud.AllOf(dns1cloud.FilterHostName("domain.com"), dns1cloud.FilterType(dns1cloud.RecordTypeA)),
+			expIDs:   []uint64{1},
+		},
+		{
+			name:     "relative CNAME target",
+			domainID: testDomainID,
+			filter:   dns1cloud.FilterValue("www"),
+			expIDs:   []uint64{4},
+		},
+		{
+			name:     "apex",
+			domainID: testDomainID,
+			filter:   dns1cloud.FilterHostName(""),
+			expIDs:   []uint64{1},
+		},
+		{
+			name:     "CNAME alias and target",
+			domainID: testDomainID,
+			filter:   dns1cloud.AllOf(dns1cloud.FilterHostName("ftp"), dns1cloud.FilterValue("www.domain.com.")),
+			expIDs:   []uint64{4},
+		},
+		{
+			name:     "SRV name",
+			domainID: testDomainID,
+			filter:   dns1cloud.FilterHostName("_sip._tcp.domain.com."),
+			expIDs:   []uint64{6},
+		},
+		{
+			name:     "SRV target",
+			domainID: testDomainID,
+			filter:   dns1cloud.AllOf(dns1cloud.FilterType(dns1cloud.RecordTypeSRV), dns1cloud.FilterValue("sip.domain.com")),
+			expIDs:   []uint64{6},
+		},
+		{
+			name:     "by value",
+			domainID: testDomainID,
+			filter:   dns1cloud.FilterValue("hello"),
+			expIDs:   []uint64{5},
+		},
+		{
+			name:     "by TTL",
+			domainID: testDomainID,
+			filter:   dns1cloud.FilterTTL(func(ttl uint32) bool { return ttl < 600 }),
+			expIDs:   []uint64{4, 5, 6},
+		},
+		{
+			name:         "nothing matched",
+			domainID:     testDomainID,
+			filter:       dns1cloud.FilterType(dns1cloud.RecordTypeMX),
+			expErrString: `matching record in domain "domain.com" is not found`,
+		},
+		{
+			name:         "unknown domain",
+			domainID:     100,
+			expErrString: `could not find records: could not send command get_domain: bad response, status: 404, body: '{"Message":"Domain is not found"}` + "\n'",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			records, err := c.FindRecords(context.Background(), tc.domainID, tc.filter)
+			if len(tc.expErrString) > 0 {
+				assert.EqualError(t, err, tc.expErrString)
+				assert.True(t, dns1cloud.IsNotFound(err))
+			} else {
+				assert.NoError(t, err)
+			}
+
+			var ids []uint64
+			for _, r := range records {
+				ids = append(ids, r.ID)
+			}
+			assert.Equal(t, tc.expIDs, ids)
+		})
+	}
+}
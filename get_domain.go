@@ -9,14 +9,17 @@ import (
 )
 
 // GetDomain returns domain by id
-func (c *DNS1Cloud) GetDomain(ctx context.Context, domainID uint64) (Domain, error) {
+func (c *DNS1Cloud) GetDomain(ctx context.Context, domainID uint64) (domain Domain, err error) {
+	ctx, sp := c.startSpan(ctx, "get_domain", Attribute{Key: attrDomainID, Value: domainID})
+	defer func() { sp.end(err) }()
+
 	cmd := command{
+		name:     "get_domain",
 		method:   http.MethodGet,
 		endpoint: fmt.Sprintf("dns/%d", domainID),
 	}
 
-	var domain Domain
-	if err := c.send(ctx, cmd, &domain); err != nil {
+	if err = c.send(ctx, cmd, &domain); err != nil {
 		return domain, errors.Wrap(err, "could not send command get_domain")
 	}
 	return domain, nil
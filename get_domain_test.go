@@ -90,6 +90,43 @@ func TestDNS1Cloud_GetDomain(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:           "success with unknown record type",
+			responseStatus: http.StatusOK,
+			responseJSON:   `{"ID":123,"Name":"domain.com","TechName":"domain_com","State":"Active","DateCreate":"2019-02-02T21:17:11.64","IsDelegate":false,"LinkedRecords":[{"ID":124,"TypeRecord":"NAPTR","HostName":"@","State":"Active","TTL":3600},{"ID":125,"TypeRecord":"A","IP":"1.1.1.1","HostName":"@","State":"Active","TTL":3600}]}`,
+			expDomain: Domain{
+				ID:         123,
+				Name:       "domain.com",
+				TechName:   "domain_com",
+				State:      StateActive,
+				DateCreate: DateTime{Time: time.Date(2019, 2, 2, 21, 17, 11, 640000000, time.UTC)},
+				LinkedRecords: []Record{
+					{
+						ID:            124,
+						TypeRecord:    RecordTypeUnknown,
+						RawTypeRecord: "NAPTR",
+						HostName:      "@",
+						State:         StateActive,
+						TTL:           3600,
+					},
+					{
+						ID:         125,
+						TypeRecord: RecordTypeA,
+						IP:         "1.1.1.1",
+						HostName:   "@",
+						State:      StateActive,
+						TTL:        3600,
+					},
+				},
+			},
+		},
+		{
+			name:           "invalid record type",
+			responseStatus: http.StatusOK,
+			responseJSON:   `{"ID":123,"LinkedRecords":[{"ID":124,"TypeRecord":12}]}`,
+			expDomain:      Domain{ID: 123, LinkedRecords: []Record{{ID: 124}}},
+			expErrString:   "could not send command get_domain: could not unmarshal response: unknown record type 12",
+		},
 		{
 			name:           "invalid json",
 			responseStatus: http.StatusOK,
@@ -9,14 +9,17 @@ import (
 )
 
 // GetRecord returns record by id
-func (c *DNS1Cloud) GetRecord(ctx context.Context, recordID uint64) (Record, error) {
+func (c *DNS1Cloud) GetRecord(ctx context.Context, recordID uint64) (record Record, err error) {
+	ctx, sp := c.startSpan(ctx, "get_record", Attribute{Key: attrRecordID, Value: recordID})
+	defer func() { sp.end(err) }()
+
 	cmd := command{
+		name:     "get_record",
 		method:   http.MethodGet,
 		endpoint: fmt.Sprintf("dns/record/%d", recordID),
 	}
 
-	var record Record
-	if err := c.send(ctx, cmd, &record); err != nil {
+	if err = c.send(ctx, cmd, &record); err != nil {
 		return record, errors.Wrap(err, "could not send command get_record")
 	}
 	return record, nil
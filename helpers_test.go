@@ -0,0 +1,31 @@
+package dns1cloud_test
+
+import (
+	"net/http"
+
+	"github.com/reinventer/dns1cloud"
+	"github.com/reinventer/dns1cloud/dns1cloudtest"
+)
+
+// testDomainID is ID of domain.com created by newTestServer
+const testDomainID = 1
+
+// badRequestBody is body of the response failed by fault with status 400
+const badRequestBody = `{"Message":"Bad Request"}` + "\n"
+
+// newTestServer returns fake server with domain.com having the records,
+// records get IDs from 1 in order
+func newTestServer(records ...dns1cloud.Record) *dns1cloudtest.Server {
+	srv := dns1cloudtest.NewServer("apiKey")
+	d := srv.AddDomain("domain.com")
+	for _, r := range records {
+		srv.AddRecord(d.ID, r)
+	}
+	return srv
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
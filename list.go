@@ -8,14 +8,17 @@ import (
 )
 
 // List returns list of domains
-func (c *DNS1Cloud) List(ctx context.Context) ([]Domain, error) {
+func (c *DNS1Cloud) List(ctx context.Context) (domains []Domain, err error) {
+	ctx, sp := c.startSpan(ctx, "list")
+	defer func() { sp.end(err) }()
+
 	cmd := command{
+		name:     "list",
 		method:   http.MethodGet,
 		endpoint: "dns",
 	}
 
-	var domains []Domain
-	if err := c.send(ctx, cmd, &domains); err != nil {
+	if err = c.send(ctx, cmd, &domains); err != nil {
 		return nil, errors.Wrap(err, "could not send command list")
 	}
 	return domains, nil
@@ -0,0 +1,112 @@
+package dns1cloud
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+)
+
+// redacted replaces secrets in logs
+const redacted = "REDACTED"
+
+// Logger is a structured logger, *slog.Logger satisfies it.
+// Arguments are alternating keys and values
+type Logger interface {
+	DebugContext(ctx context.Context, msg string, args ...interface{})
+	InfoContext(ctx context.Context, msg string, args ...interface{})
+	WarnContext(ctx context.Context, msg string, args ...interface{})
+}
+
+// WithLogger is option function for setting logger of requests.
+// Every attempt of every command is logged with its method, endpoint, duration, status and size of response
+func WithLogger(l Logger) OptFunc {
+	return func(c *DNS1Cloud) {
+		c.logger = l
+	}
+}
+
+// WithBodyLogging is option function which enables logging of headers and bodies of
+// requests and responses on debug level. Authorization header is always redacted
+func WithBodyLogging() OptFunc {
+	return func(c *DNS1Cloud) {
+		c.logBodies = true
+	}
+}
+
+// responseBody counts bytes read from body of response and keeps them if buf is set
+type responseBody struct {
+	r   io.Reader
+	n   int
+	buf *bytes.Buffer
+}
+
+func (b *responseBody) Read(p []byte) (int, error) {
+	n, err := b.r.Read(p)
+	b.n += n
+	if b.buf != nil {
+		b.buf.Write(p[:n])
+	}
+	return n, err
+}
+
+func (c *DNS1Cloud) logAttempt(
+	ctx context.Context,
+	cmd command,
+	req *http.Request,
+	token string,
+	duration time.Duration,
+	status int,
+	body *responseBody,
+	err error,
+) {
+	args := []interface{}{
+		"command", cmd.name,
+		"method", cmd.method,
+		"endpoint", cmd.endpoint,
+		"duration", duration,
+		"status", status,
+		"size", body.n,
+	}
+
+	if err != nil {
+		c.logger.WarnContext(ctx, "dns1cloud request failed", append(args, "error", redact(err.Error(), token))...)
+	} else {
+		c.logger.InfoContext(ctx, "dns1cloud request", args...)
+	}
+
+	if !c.logBodies {
+		return
+	}
+
+	var reqBody string
+	if cmd.params != nil {
+		if b, err := json.Marshal(cmd.params); err == nil {
+			reqBody = string(b)
+		}
+	}
+	var respBody string
+	if body.buf != nil {
+		respBody = body.buf.String()
+	}
+
+	c.logger.DebugContext(ctx, "dns1cloud request body",
+		"command", cmd.name,
+		"request_headers", redactHeader(req.Header),
+		"request_body", redact(reqBody, token),
+		"response_body", redact(respBody, token),
+	)
+}
+
+func redactHeader(h http.Header) http.Header {
+	res := make(http.Header, len(h))
+	for k, v := range h {
+		if http.CanonicalHeaderKey(k) == "Authorization" {
+			v = []string{redacted}
+		}
+		res[k] = v
+	}
+	return res
+}
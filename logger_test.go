@@ -0,0 +1,136 @@
+package dns1cloud
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type logEntry struct {
+	level string
+	msg   string
+	attrs map[string]interface{}
+}
+
+type testLogger struct {
+	mu      sync.Mutex
+	entries []logEntry
+}
+
+func (l *testLogger) log(level, msg string, args []interface{}) {
+	e := logEntry{level: level, msg: msg, attrs: map[string]interface{}{}}
+	for i := 0; i+1 < len(args); i += 2 {
+		e.attrs[args[i].(string)] = args[i+1]
+	}
+	l.mu.Lock()
+	l.entries = append(l.entries, e)
+	l.mu.Unlock()
+}
+
+func (l *testLogger) DebugContext(_ context.Context, msg string, args ...interface{}) {
+	l.log("debug", msg, args)
+}
+
+func (l *testLogger) InfoContext(_ context.Context, msg string, args ...interface{}) {
+	l.log("info", msg, args)
+}
+
+func (l *testLogger) WarnContext(_ context.Context, msg string, args ...interface{}) {
+	l.log("warn", msg, args)
+}
+
+func TestDNS1Cloud_WithLogger(t *testing.T) {
+	const apiKey = "secret-key"
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/dns/1":
+			w.Write([]byte(`{"ID":1,"Name":"domain.com"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"Message":"token secret-key is not found"}`))
+		}
+	}))
+	defer s.Close()
+
+	testCases := []struct {
+		name       string
+		opts       []OptFunc
+		expEntries int
+	}{
+		{
+			name:       "without bodies",
+			expEntries: 2,
+		},
+		{
+			name:       "with bodies",
+			opts:       []OptFunc{WithBodyLogging()},
+			expEntries: 4,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			l := &testLogger{}
+			c := New(apiKey, append([]OptFunc{WithApiHost(s.URL), WithLogger(l)}, tc.opts...)...)
+
+			_, err := c.GetDomain(context.Background(), 1)
+			require.NoError(t, err)
+			_, err = c.GetRecord(context.Background(), 2)
+			require.Error(t, err)
+
+			require.Len(t, l.entries, tc.expEntries)
+
+			var info, warn, debug logEntry
+			for _, e := range l.entries {
+				switch e.level {
+				case "info":
+					info = e
+				case "warn":
+					warn = e
+				case "debug":
+					if e.attrs["command"] == "get_domain" {
+						debug = e
+					}
+				}
+				assert.NotContains(t, fmt.Sprint(e.attrs), apiKey)
+			}
+
+			assert.Equal(t, "dns1cloud request", info.msg)
+			assert.Equal(t, "get_domain", info.attrs["command"])
+			assert.Equal(t, http.MethodGet, info.attrs["method"])
+			assert.Equal(t, "dns/1", info.attrs["endpoint"])
+			assert.Equal(t, http.StatusOK, info.attrs["status"])
+			assert.Equal(t, len(`{"ID":1,"Name":"domain.com"}`), info.attrs["size"])
+			assert.Contains(t, info.attrs, "duration")
+
+			assert.Equal(t, "dns1cloud request failed", warn.msg)
+			assert.Equal(t, "get_record", warn.attrs["command"])
+			assert.Equal(t, http.StatusNotFound, warn.attrs["status"])
+			assert.True(t, strings.Contains(warn.attrs["error"].(string), "token REDACTED is not found"))
+
+			if tc.expEntries > 2 {
+				assert.Equal(t, `{"ID":1,"Name":"domain.com"}`, debug.attrs["response_body"])
+				assert.Equal(t, "REDACTED", debug.attrs["request_headers"].(http.Header).Get("Authorization"))
+			}
+		})
+	}
+}
+
+func TestRedactHeader(t *testing.T) {
+	h := http.Header{}
+	h.Set("Authorization", "Bearer key")
+	h.Set("Content-Type", "application/json")
+
+	res := redactHeader(h)
+	assert.Equal(t, "REDACTED", res.Get("Authorization"))
+	assert.Equal(t, "application/json", res.Get("Content-Type"))
+	assert.Equal(t, "Bearer key", h.Get("Authorization"))
+}
@@ -23,20 +23,30 @@ const (
 // DNS1Cloud represents a client for API of 1Cloud's DNS hosting
 type DNS1Cloud struct {
 	apiHost string
-	apiKey  string
+	tokens  TokenSource
 	client  *http.Client
+
+	retryPolicy *RetryPolicy
+	limiter     *rateLimiter
+
+	logger    Logger
+	logBodies bool
+	metrics   Metrics
+	tracer    Tracer
 }
 
 // New creates and return new DNS1Cloud
 func New(apiKey string, opts ...OptFunc) *DNS1Cloud {
-	c := &DNS1Cloud{
-		apiKey: apiKey,
-	}
+	c := &DNS1Cloud{}
 
 	for _, f := range opts {
 		f(c)
 	}
 
+	if c.tokens == nil {
+		c.tokens = StaticTokenSource(apiKey)
+	}
+
 	if c.client == nil {
 		c.client = &http.Client{Timeout: defaultTimeout}
 	}
@@ -66,45 +76,120 @@ func WithApiHost(apiHost string) OptFunc {
 }
 
 type command struct {
+	// name is name of the command used in logs, e.g. "get_domain"
+	name     string
 	method   string
 	endpoint string
 	params   interface{}
 }
 
 func (c *DNS1Cloud) send(ctx context.Context, cmd command, response interface{}) error {
-	req, err := c.getRequest(cmd)
+	token, err := c.tokens.Token(ctx)
 	if err != nil {
-		return errors.Wrap(err, "could not get request")
+		return errors.Wrap(err, "could not get API key")
 	}
 
-	req = req.WithContext(ctx)
+	refreshed := false
+	for attempt := 1; ; attempt++ {
+		if c.limiter != nil {
+			started := time.Now()
+			err := c.limiter.wait(ctx)
+			if c.metrics != nil {
+				c.metrics.ObserveRateLimitWait(cmd.name, time.Since(started))
+			}
+			if err != nil {
+				return errors.Wrap(err, "could not wait for rate limiter")
+			}
+		}
+
+		retryable, err := c.do(ctx, cmd, token, response)
+		if err == nil {
+			return nil
+		}
+
+		// the key may be rotated, the request is repeated once with the new key
+		if IsUnauthorized(err) && !refreshed {
+			refreshed = true
+			newToken, refreshErr := c.tokens.Refresh(ctx)
+			if refreshErr == nil && newToken != token {
+				token = newToken
+				continue
+			}
+		}
+
+		wait, ok := c.retryPolicy.next(cmd, attempt, retryable, err)
+		if !ok {
+			return err
+		}
+		if c.metrics != nil {
+			c.metrics.IncRetry(cmd.name)
+		}
+
+		if ctxErr := sleepContext(ctx, wait); ctxErr != nil {
+			return errors.Wrapf(ctxErr, "could not wait for retry after error: %s", err)
+		}
+	}
+}
+
+// do makes one attempt to send the command and reports whether failed attempt could be retried
+func (c *DNS1Cloud) do(ctx context.Context, cmd command, token string, response interface{}) (retryable bool, err error) {
+	req, err := c.getRequest(ctx, cmd, token)
+	if err != nil {
+		return false, errors.Wrap(err, "could not get request")
+	}
+
+	var (
+		started = time.Now()
+		status  int
+		body    = &responseBody{}
+	)
+	if c.logger != nil && c.logBodies {
+		body.buf = &bytes.Buffer{}
+	}
+	if c.logger != nil || c.metrics != nil {
+		defer func() {
+			d := time.Since(started)
+			if c.metrics != nil {
+				c.metrics.ObserveRequest(cmd.name, statusClass(status), d)
+			}
+			if c.logger != nil {
+				c.logAttempt(ctx, cmd, req, token, d, status, body, err)
+			}
+		}()
+	}
 
 	resp, err := c.client.Do(req)
 	if err != nil {
-		return errors.Wrap(err, "could not do http request")
+		return ctx.Err() == nil, errors.Wrap(err, "could not do http request")
 	}
 	defer resp.Body.Close()
 
+	status = resp.StatusCode
+	spanFromContext(ctx).setAttributes(Attribute{Key: attrHTTPStatus, Value: status})
+	body.r = resp.Body
+
 	if resp.StatusCode != http.StatusOK {
-		body, err := ioutil.ReadAll(resp.Body)
+		b, err := ioutil.ReadAll(body)
 		if err != nil {
-			return errors.Wrapf(err, "could not read body of failed response, code: %d", resp.StatusCode)
+			return true, errors.Wrapf(err, "could not read body of failed response, code: %d", resp.StatusCode)
 		}
-		return errors.Errorf("bad response, status: %d, body: '%s'", resp.StatusCode, string(body))
+		apiErr := newAPIError(cmd, resp.StatusCode, []byte(redact(string(b), token)))
+		apiErr.retryAfter = parseRetryAfter(resp.Header.Get("Retry-After"))
+		return isRetryableStatus(resp.StatusCode), apiErr
 	}
 
 	if response != nil {
-		dec := json.NewDecoder(resp.Body)
+		dec := json.NewDecoder(body)
 
 		if err = dec.Decode(response); err != nil {
-			return errors.Wrap(err, "could not unmarshal response")
+			return false, errors.Wrap(err, "could not unmarshal response")
 		}
 	}
 
-	return nil
+	return false, nil
 }
 
-func (c *DNS1Cloud) getRequest(cmd command) (*http.Request, error) {
+func (c *DNS1Cloud) getRequest(ctx context.Context, cmd command, token string) (*http.Request, error) {
 	url := c.apiHost
 	if len(cmd.endpoint) > 0 {
 		url = strings.Join([]string{url, cmd.endpoint}, "/")
@@ -123,7 +208,8 @@ func (c *DNS1Cloud) getRequest(cmd command) (*http.Request, error) {
 		body = bytes.NewBuffer(b)
 	}
 
-	req, err := http.NewRequest(
+	req, err := http.NewRequestWithContext(
+		ctx,
 		cmd.method,
 		url,
 		body,
@@ -133,8 +219,11 @@ func (c *DNS1Cloud) getRequest(cmd command) (*http.Request, error) {
 	}
 
 	req.Header.Add("Content-Type", "application/json")
-	if len(c.apiKey) > 0 {
-		req.Header.Add("Authorization", "Bearer "+c.apiKey)
+	if len(token) > 0 {
+		req.Header.Add("Authorization", "Bearer "+token)
+	}
+	if c.tracer != nil {
+		c.tracer.Inject(ctx, req.Header)
 	}
 
 	return req, nil
@@ -143,10 +232,8 @@ func (c *DNS1Cloud) getRequest(cmd command) (*http.Request, error) {
 func getTTL(ttl uint32) (string, error) {
 	if ttl != 0 {
 		str := strconv.FormatUint(uint64(ttl), 10)
-		for _, t := range validTTLs {
-			if t == str {
-				return str, nil
-			}
+		if IsValidTTL(ttl) {
+			return str, nil
 		}
 		return "", errors.Errorf("TTL %q is not valid", str)
 	}
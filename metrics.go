@@ -0,0 +1,36 @@
+package dns1cloud
+
+import (
+	"strconv"
+	"time"
+)
+
+// Metrics receives measurements of API calls, it may be backed by Prometheus,
+// OpenTelemetry or any other metrics library. Methods are called concurrently.
+//
+// Command is name of the command: list, get_domain, get_record, add_domain,
+// delete_domain, add_record, update_record or delete_record
+type Metrics interface {
+	// ObserveRequest is called after every attempt of a command with class of HTTP status,
+	// e.g. "2xx" or "5xx", or "error" if no response was received, and duration of the attempt
+	ObserveRequest(command, statusClass string, duration time.Duration)
+	// IncRetry is called before the command is retried
+	IncRetry(command string)
+	// ObserveRateLimitWait is called with time spent waiting for the rate limiter before an attempt
+	ObserveRateLimitWait(command string, duration time.Duration)
+}
+
+// WithMetrics is option function for setting receiver of metrics
+func WithMetrics(m Metrics) OptFunc {
+	return func(c *DNS1Cloud) {
+		c.metrics = m
+	}
+}
+
+// statusClass returns class of HTTP status, zero status means that no response was received
+func statusClass(status int) string {
+	if status < 100 || status > 599 {
+		return "error"
+	}
+	return strconv.Itoa(status/100) + "xx"
+}
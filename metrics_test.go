@@ -0,0 +1,105 @@
+package dns1cloud
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testMetrics struct {
+	mu       sync.Mutex
+	requests map[string]int
+	retries  map[string]int
+	waits    map[string]int
+}
+
+func newTestMetrics() *testMetrics {
+	return &testMetrics{
+		requests: map[string]int{},
+		retries:  map[string]int{},
+		waits:    map[string]int{},
+	}
+}
+
+func (m *testMetrics) ObserveRequest(command, statusClass string, duration time.Duration) {
+	m.mu.Lock()
+	m.requests[command+" "+statusClass]++
+	m.mu.Unlock()
+}
+
+func (m *testMetrics) IncRetry(command string) {
+	m.mu.Lock()
+	m.retries[command]++
+	m.mu.Unlock()
+}
+
+func (m *testMetrics) ObserveRateLimitWait(command string, duration time.Duration) {
+	m.mu.Lock()
+	m.waits[command]++
+	m.mu.Unlock()
+}
+
+func TestDNS1Cloud_WithMetrics(t *testing.T) {
+	var failures int32 = 1
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/dns/1" && atomic.AddInt32(&failures, -1) >= 0:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case r.URL.Path == "/dns/1":
+			w.Write([]byte(`{"ID":1}`))
+		case r.URL.Path == "/dns":
+			w.Write([]byte(`[]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer s.Close()
+
+	m := newTestMetrics()
+	c := New("apiKey",
+		WithApiHost(s.URL),
+		WithRetryPolicy(testRetryPolicy),
+		WithRateLimit(1000, 10),
+		WithMetrics(m),
+	)
+
+	_, err := c.GetDomain(context.Background(), 1)
+	require.NoError(t, err)
+	_, err = c.List(context.Background())
+	require.NoError(t, err)
+	_, err = c.GetRecord(context.Background(), 1)
+	require.Error(t, err)
+
+	assert.Equal(t, map[string]int{
+		"get_domain 5xx": 1,
+		"get_domain 2xx": 1,
+		"list 2xx":       1,
+		"get_record 4xx": 1,
+	}, m.requests)
+	assert.Equal(t, map[string]int{"get_domain": 1}, m.retries)
+	assert.Equal(t, map[string]int{"get_domain": 2, "list": 1, "get_record": 1}, m.waits)
+}
+
+func TestStatusClass(t *testing.T) {
+	testCases := []struct {
+		status int
+		exp    string
+	}{
+		{status: 0, exp: "error"},
+		{status: 200, exp: "2xx"},
+		{status: 301, exp: "3xx"},
+		{status: 429, exp: "4xx"},
+		{status: 503, exp: "5xx"},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.exp, statusClass(tc.status))
+	}
+}
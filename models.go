@@ -1,7 +1,10 @@
 package dns1cloud
 
 import (
+	"encoding/json"
 	"fmt"
+	"reflect"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -14,18 +17,68 @@ const (
 	StateActive
 )
 
+var stateNames = [...]string{
+	StateNew:    "New",
+	StateActive: "Active",
+}
+
+// ParseState returns state by its name
+func ParseState(name string) (State, error) {
+	for s, n := range stateNames {
+		if strings.EqualFold(n, name) {
+			return State(s), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown state %q", name)
+}
+
+// String returns name of the state
+func (s State) String() string {
+	if int(s) < len(stateNames) {
+		return stateNames[s]
+	}
+	return "State(" + strconv.Itoa(int(s)) + ")"
+}
+
+// MarshalText returns name of the state
+func (s State) MarshalText() ([]byte, error) {
+	if int(s) < len(stateNames) {
+		return []byte(stateNames[s]), nil
+	}
+	return nil, fmt.Errorf("unknown state %d", s)
+}
+
+// UnmarshalText sets state from its name
+func (s *State) UnmarshalText(b []byte) error {
+	state, err := ParseState(string(b))
+	if err != nil {
+		return err
+	}
+	*s = state
+	return nil
+}
+
+// MarshalJSON returns JSON bytes of state
+func (s State) MarshalJSON() ([]byte, error) {
+	b, err := s.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(b))
+}
+
 // UnmarshalJSON sets state of domain or record from JSON bytes
 func (s *State) UnmarshalJSON(b []byte) error {
-	state := string(b)
-	switch state {
-	case "null", `"New"`:
+	if string(b) == "null" {
 		*s = StateNew
-	case `"Active"`:
-		*s = StateActive
-	default:
-		return fmt.Errorf("unknown state %s", state)
+		return nil
 	}
-	return nil
+
+	var name string
+	if err := json.Unmarshal(b, &name); err != nil {
+		return fmt.Errorf("unknown state %s", b)
+	}
+	return s.UnmarshalText([]byte(name))
 }
 
 var dateTimeLayouts = []string{
@@ -62,6 +115,14 @@ func (d *DateTime) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalJSON returns JSON bytes of time in the layout used by API
+func (d DateTime) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(d.Format(dateTimeLayouts[0]))
+}
+
 // RecordType is a type of record
 type RecordType uint8
 
@@ -80,29 +141,89 @@ const (
 	RecordTypeNS
 	// RecordTypeSRV is a type "SRV"
 	RecordTypeSRV
+	// RecordTypeCAA is a type "CAA"
+	RecordTypeCAA
+	// RecordTypePTR is a type "PTR"
+	RecordTypePTR
+
+	// RecordTypeUnknown is a type which is not supported by the client,
+	// the original name of the type is kept in Record.RawTypeRecord
+	RecordTypeUnknown RecordType = 255
 )
 
-// UnmarshalJSON sets record type from JSON bytes
+var recordTypeNames = [...]string{
+	RecordTypeA:     "A",
+	RecordTypeAAAA:  "AAAA",
+	RecordTypeMX:    "MX",
+	RecordTypeCNAME: "CNAME",
+	RecordTypeTXT:   "TXT",
+	RecordTypeNS:    "NS",
+	RecordTypeSRV:   "SRV",
+	RecordTypeCAA:   "CAA",
+	RecordTypePTR:   "PTR",
+}
+
+// ParseRecordType returns record type by its name, e.g. "CNAME"
+func ParseRecordType(name string) (RecordType, error) {
+	for t, n := range recordTypeNames {
+		if strings.EqualFold(n, name) {
+			return RecordType(t), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown record type %q", name)
+}
+
+// String returns name of the record type
+func (r RecordType) String() string {
+	if int(r) < len(recordTypeNames) {
+		return recordTypeNames[r]
+	}
+	if r == RecordTypeUnknown {
+		return "Unknown"
+	}
+	return "RecordType(" + strconv.Itoa(int(r)) + ")"
+}
+
+// MarshalText returns name of the record type
+func (r RecordType) MarshalText() ([]byte, error) {
+	if int(r) < len(recordTypeNames) {
+		return []byte(recordTypeNames[r]), nil
+	}
+	return nil, fmt.Errorf("unknown record type %d", r)
+}
+
+// UnmarshalText sets record type from its name
+func (r *RecordType) UnmarshalText(b []byte) error {
+	t, err := ParseRecordType(string(b))
+	if err != nil {
+		return err
+	}
+	*r = t
+	return nil
+}
+
+// MarshalJSON returns JSON bytes of record type
+func (r RecordType) MarshalJSON() ([]byte, error) {
+	b, err := r.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(b))
+}
+
+// UnmarshalJSON sets record type from JSON bytes.
+// Types unknown to the client are decoded as RecordTypeUnknown
 func (r *RecordType) UnmarshalJSON(b []byte) error {
-	t := string(b)
-	switch t {
-	case `"A"`:
-		*r = RecordTypeA
-	case `"AAAA"`:
-		*r = RecordTypeAAAA
-	case `"MX"`:
-		*r = RecordTypeMX
-	case `"CNAME"`:
-		*r = RecordTypeCNAME
-	case `"TXT"`:
-		*r = RecordTypeTXT
-	case `"NS"`:
-		*r = RecordTypeNS
-	case `"SRV"`:
-		*r = RecordTypeSRV
-	default:
-		return fmt.Errorf("unknown record type %s", t)
+	var name string
+	if err := json.Unmarshal(b, &name); err != nil {
+		return fmt.Errorf("unknown record type %s", b)
+	}
+
+	t, err := ParseRecordType(name)
+	if err != nil || t.String() != name {
+		t = RecordTypeUnknown
 	}
+	*r = t
 	return nil
 }
 
@@ -117,7 +238,7 @@ type Domain struct {
 	LinkedRecords []Record `json:"LinkedRecords"`
 }
 
-// Domain represents record of domain
+// Record represents record of domain
 type Record struct {
 	ID                   uint64     `json:"ID"`
 	TypeRecord           RecordType `json:"TypeRecord"`
@@ -132,8 +253,63 @@ type Record struct {
 	Target               string     `json:"Target"`
 	Proto                string     `json:"Proto"`
 	Service              string     `json:"Service"`
+	Flag                 string     `json:"Flag"`
+	Tag                  string     `json:"Tag"`
+	Value                string     `json:"Value"`
 	TTL                  uint32     `json:"TTL"`
 	State                State      `json:"State"`
 	DateCreate           DateTime   `json:"DateCreate"`
 	CanonicalDescription string     `json:"CanonicalDescription"`
+
+	// RawTypeRecord is the original name of the type when TypeRecord is RecordTypeUnknown
+	RawTypeRecord string `json:"-"`
+}
+
+// UnmarshalJSON sets record from JSON bytes keeping the name of unknown record type
+func (r *Record) UnmarshalJSON(b []byte) error {
+	type record Record
+	var aux struct {
+		record
+		TypeRecord json.RawMessage `json:"TypeRecord"`
+	}
+	if err := json.Unmarshal(b, &aux); err != nil {
+		if e, ok := err.(*json.UnmarshalTypeError); ok && len(e.Field) == 0 {
+			// hide the auxiliary type from the error message
+			e.Type = reflect.TypeOf(*r)
+		}
+		return err
+	}
+
+	*r = Record(aux.record)
+	if len(aux.TypeRecord) == 0 {
+		return nil
+	}
+
+	if err := r.TypeRecord.UnmarshalJSON(aux.TypeRecord); err != nil {
+		return err
+	}
+	if r.TypeRecord == RecordTypeUnknown {
+		return json.Unmarshal(aux.TypeRecord, &r.RawTypeRecord)
+	}
+	return nil
+}
+
+// MarshalJSON returns JSON bytes of record keeping the name of unknown record type
+func (r Record) MarshalJSON() ([]byte, error) {
+	type record Record
+	// ID and TypeRecord shadow the fields of embedded record
+	// and keep their position in the object
+	aux := struct {
+		ID         uint64      `json:"ID"`
+		TypeRecord interface{} `json:"TypeRecord"`
+		record
+	}{
+		ID:         r.ID,
+		TypeRecord: r.TypeRecord,
+		record:     record(r),
+	}
+	if r.TypeRecord == RecordTypeUnknown {
+		aux.TypeRecord = r.RawTypeRecord
+	}
+	return json.Marshal(aux)
 }
@@ -0,0 +1,179 @@
+package dns1cloud
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordType_RoundTrip(t *testing.T) {
+	testCases := []struct {
+		recordType RecordType
+		name       string
+	}{
+		{RecordTypeA, "A"},
+		{RecordTypeAAAA, "AAAA"},
+		{RecordTypeMX, "MX"},
+		{RecordTypeCNAME, "CNAME"},
+		{RecordTypeTXT, "TXT"},
+		{RecordTypeNS, "NS"},
+		{RecordTypeSRV, "SRV"},
+		{RecordTypeCAA, "CAA"},
+		{RecordTypePTR, "PTR"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.name, tc.recordType.String())
+
+			parsed, err := ParseRecordType(tc.name)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.recordType, parsed)
+
+			text, err := tc.recordType.MarshalText()
+			assert.NoError(t, err)
+			assert.Equal(t, tc.name, string(text))
+
+			var fromText RecordType
+			assert.NoError(t, fromText.UnmarshalText(text))
+			assert.Equal(t, tc.recordType, fromText)
+
+			b, err := json.Marshal(tc.recordType)
+			assert.NoError(t, err)
+			assert.Equal(t, `"`+tc.name+`"`, string(b))
+
+			var fromJSON RecordType
+			assert.NoError(t, json.Unmarshal(b, &fromJSON))
+			assert.Equal(t, tc.recordType, fromJSON)
+		})
+	}
+}
+
+func TestRecordType_Invalid(t *testing.T) {
+	assert.Equal(t, "Unknown", RecordTypeUnknown.String())
+	assert.Equal(t, "RecordType(100)", RecordType(100).String())
+
+	parsed, err := ParseRecordType("cname")
+	assert.NoError(t, err)
+	assert.Equal(t, RecordTypeCNAME, parsed)
+
+	_, err = ParseRecordType("NAPTR")
+	assert.EqualError(t, err, `unknown record type "NAPTR"`)
+
+	_, err = json.Marshal(RecordTypeUnknown)
+	assert.Error(t, err)
+
+	var r RecordType
+	assert.EqualError(t, r.UnmarshalText([]byte("NAPTR")), `unknown record type "NAPTR"`)
+	assert.NoError(t, json.Unmarshal([]byte(`"NAPTR"`), &r))
+	assert.Equal(t, RecordTypeUnknown, r)
+}
+
+func TestState_RoundTrip(t *testing.T) {
+	testCases := []struct {
+		state State
+		name  string
+	}{
+		{StateNew, "New"},
+		{StateActive, "Active"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.name, tc.state.String())
+
+			parsed, err := ParseState(tc.name)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.state, parsed)
+
+			text, err := tc.state.MarshalText()
+			assert.NoError(t, err)
+
+			var fromText State
+			assert.NoError(t, fromText.UnmarshalText(text))
+			assert.Equal(t, tc.state, fromText)
+
+			b, err := json.Marshal(tc.state)
+			assert.NoError(t, err)
+			assert.Equal(t, `"`+tc.name+`"`, string(b))
+
+			var fromJSON State
+			assert.NoError(t, json.Unmarshal(b, &fromJSON))
+			assert.Equal(t, tc.state, fromJSON)
+		})
+	}
+
+	assert.Equal(t, "State(7)", State(7).String())
+	_, err := ParseState("Deleted")
+	assert.EqualError(t, err, `unknown state "Deleted"`)
+
+	var fromJSON State
+	assert.NoError(t, json.Unmarshal([]byte(`"active"`), &fromJSON))
+	assert.Equal(t, StateActive, fromJSON)
+	assert.NoError(t, json.Unmarshal([]byte(`null`), &fromJSON))
+	assert.Equal(t, StateNew, fromJSON)
+	assert.EqualError(t, json.Unmarshal([]byte(`"Deleted"`), &fromJSON), `unknown state "Deleted"`)
+	assert.EqualError(t, json.Unmarshal([]byte(`1`), &fromJSON), `unknown state 1`)
+}
+
+func TestDateTime_RoundTrip(t *testing.T) {
+	d := DateTime{Time: time.Date(2019, 2, 2, 21, 17, 11, 640000000, time.UTC)}
+
+	b, err := json.Marshal(d)
+	assert.NoError(t, err)
+	assert.Equal(t, `"2019-02-02T21:17:11.64"`, string(b))
+
+	var parsed DateTime
+	assert.NoError(t, json.Unmarshal(b, &parsed))
+	assert.Equal(t, d, parsed)
+
+	b, err = json.Marshal(DateTime{})
+	assert.NoError(t, err)
+	assert.Equal(t, "null", string(b))
+}
+
+func TestRecord_RoundTrip(t *testing.T) {
+	records := []Record{
+		{
+			ID:                   124,
+			TypeRecord:           RecordTypeSRV,
+			HostName:             "@",
+			Priority:             "20",
+			Weight:               "0",
+			Port:                 "5222",
+			Target:               "domain-xmpp.test.com.",
+			Proto:                "tcp",
+			Service:              "_xmpp-client.",
+			TTL:                  21160,
+			State:                StateActive,
+			DateCreate:           DateTime{Time: time.Date(2019, 2, 2, 21, 18, 6, 743000000, time.UTC)},
+			CanonicalDescription: "_xmpp-client._tcp.domain.com. 21160 IN SRV 20 0 5222 domain-xmpp.test.com.",
+		},
+		{
+			ID:            125,
+			TypeRecord:    RecordTypeUnknown,
+			RawTypeRecord: "NAPTR",
+			HostName:      "@",
+		},
+	}
+
+	b, err := json.Marshal(records)
+	assert.NoError(t, err)
+
+	var parsed []Record
+	assert.NoError(t, json.Unmarshal(b, &parsed))
+	assert.Equal(t, records, parsed)
+}
+
+func TestRecord_MarshalJSONKeyOrder(t *testing.T) {
+	for _, r := range []Record{
+		{ID: 1, TypeRecord: RecordTypeA, IP: "1.1.1.1"},
+		{ID: 2, TypeRecord: RecordTypeUnknown, RawTypeRecord: "NAPTR"},
+	} {
+		b, err := json.Marshal(r)
+		assert.NoError(t, err)
+		assert.Regexp(t, `^\{"ID":\d+,"TypeRecord":"[A-Z]+","IP":`, string(b))
+	}
+}
@@ -0,0 +1,46 @@
+package dns1cloud
+
+import "strings"
+
+// RelativeName returns lower-cased name relative to origin in the form used by API.
+// "@" and empty name mean origin itself, "@" is returned for it.
+// Trailing dots are ignored, names outside of origin are returned as is
+func RelativeName(name, origin string) string {
+	name, origin = normalizeName(name), normalizeName(origin)
+	switch {
+	case origin == "@":
+		return name
+	case name == origin:
+		return "@"
+	case strings.HasSuffix(name, "."+origin):
+		return strings.TrimSuffix(name, "."+origin)
+	}
+	return name
+}
+
+// FQDN returns lower-cased fully qualified name with trailing dot.
+// Name with trailing dot is absolute, other names are resolved against origin
+// unless they already end with it. "@" and empty name mean origin itself
+func FQDN(name, origin string) string {
+	if strings.HasSuffix(name, ".") {
+		return strings.ToLower(name)
+	}
+
+	name, origin = RelativeName(name, origin), normalizeName(origin)
+	switch {
+	case name == "@":
+		return origin + "."
+	case origin == "@":
+		return name + "."
+	}
+	return name + "." + origin + "."
+}
+
+// normalizeName returns lower-cased name without trailing dot, "@" for empty name
+func normalizeName(name string) string {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	if len(name) == 0 {
+		return "@"
+	}
+	return name
+}
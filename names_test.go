@@ -0,0 +1,48 @@
+package dns1cloud
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRelativeName(t *testing.T) {
+	testCases := []struct {
+		name   string
+		origin string
+		exp    string
+	}{
+		{name: "", origin: "domain.com", exp: "@"},
+		{name: "@", origin: "domain.com", exp: "@"},
+		{name: "Domain.com.", origin: "domain.com", exp: "@"},
+		{name: "www", origin: "domain.com.", exp: "www"},
+		{name: "WWW.Sub.domain.com.", origin: "domain.com", exp: "www.sub"},
+		{name: "mail.test.com", origin: "domain.com", exp: "mail.test.com"},
+		{name: "notdomain.com", origin: "domain.com", exp: "notdomain.com"},
+		{name: "www.domain.com.", origin: "", exp: "www.domain.com"},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.exp, RelativeName(tc.name, tc.origin), "name %q, origin %q", tc.name, tc.origin)
+	}
+}
+
+func TestFQDN(t *testing.T) {
+	testCases := []struct {
+		name   string
+		origin string
+		exp    string
+	}{
+		{name: "", origin: "domain.com", exp: "domain.com."},
+		{name: "@", origin: "Domain.com.", exp: "domain.com."},
+		{name: "www", origin: "domain.com", exp: "www.domain.com."},
+		{name: "www.sub", origin: "domain.com", exp: "www.sub.domain.com."},
+		{name: "WWW.domain.com", origin: "domain.com", exp: "www.domain.com."},
+		{name: "mail.test.com.", origin: "domain.com", exp: "mail.test.com."},
+		{name: "www.domain.com", origin: "", exp: "www.domain.com."},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.exp, FQDN(tc.name, tc.origin), "name %q, origin %q", tc.name, tc.origin)
+	}
+}
@@ -0,0 +1,82 @@
+package dns1cloud
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// WithRateLimit is option function for limiting rate of requests to API.
+// The limiter is a token bucket refilled with rps tokens per second and
+// holding at most burst tokens. It is shared by all methods of the client.
+// Zero rps means unlimited rate, WithRateLimit panics if rps is negative
+func WithRateLimit(rps float64, burst int) OptFunc {
+	if rps < 0 || math.IsNaN(rps) {
+		panic("dns1cloud: negative rate limit")
+	}
+	return func(c *DNS1Cloud) {
+		c.limiter = newRateLimiter(rps, burst)
+	}
+}
+
+// rateLimiter is a token bucket safe for concurrent use
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(rps float64, burst int) *rateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimiter{
+		rate:   rps,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// wait blocks until a token is available or ctx is done
+func (l *rateLimiter) wait(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	// zero rate means unlimited
+	if l.rate == 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+
+	// the token is reserved in advance, so concurrent callers queue up
+	// behind each other instead of racing for the same token
+	l.tokens--
+	var d time.Duration
+	if l.tokens < 0 {
+		d = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	l.mu.Unlock()
+
+	if d == 0 {
+		return nil
+	}
+
+	if err := sleepContext(ctx, d); err != nil {
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return err
+	}
+	return nil
+}
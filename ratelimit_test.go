@@ -0,0 +1,90 @@
+package dns1cloud
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDNS1Cloud_RateLimit(t *testing.T) {
+	var requests int32
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Write([]byte("[]"))
+	}))
+	defer s.Close()
+
+	c := New("apiKey", WithApiHost(s.URL), WithRateLimit(100, 2))
+
+	start := time.Now()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := c.List(context.Background())
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	// 2 requests are served by burst, 4 more need 40ms at 100 rps
+	assert.True(t, time.Since(start) >= 35*time.Millisecond)
+	assert.Equal(t, int32(6), atomic.LoadInt32(&requests))
+}
+
+func TestDNS1Cloud_RateLimitContextCanceled(t *testing.T) {
+	var requests int32
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Write([]byte("[]"))
+	}))
+	defer s.Close()
+
+	c := New("apiKey", WithApiHost(s.URL), WithRateLimit(0.1, 1))
+
+	_, err := c.List(context.Background())
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err = c.List(ctx)
+	assert.EqualError(t, err, "could not send command list: could not wait for rate limiter: context deadline exceeded")
+	assert.True(t, time.Since(start) < time.Second)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+}
+
+func TestRateLimiter_refund(t *testing.T) {
+	l := newRateLimiter(1, 1)
+	assert.NoError(t, l.wait(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, l.wait(ctx))
+
+	// the reserved token is returned to the bucket
+	l.mu.Lock()
+	assert.True(t, l.tokens >= 0 && l.tokens < 1, "tokens: %f", l.tokens)
+	l.mu.Unlock()
+}
+
+func TestWithRateLimit_rate(t *testing.T) {
+	// zero rate means unlimited
+	l := newRateLimiter(0, 1)
+	for i := 0; i < 10; i++ {
+		assert.NoError(t, l.wait(context.Background()))
+	}
+
+	assert.Panics(t, func() { WithRateLimit(-1, 1) })
+	assert.Panics(t, func() { WithRateLimit(math.NaN(), 1) })
+}
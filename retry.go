@@ -0,0 +1,125 @@
+package dns1cloud
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RetryPolicy describes how failed commands are retried.
+// Only idempotent commands (GET, PUT and DELETE) are retried, on network
+// errors and on responses with status 429 or 5xx
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts including the first one
+	MaxAttempts int
+	// MinBackoff is the delay before the first retry
+	MinBackoff time.Duration
+	// MaxBackoff is the upper limit of the delay between attempts,
+	// including delays requested by the server with Retry-After. Zero means no limit
+	MaxBackoff time.Duration
+}
+
+// DefaultRetryPolicy is a reasonable retry policy for API of 1Cloud
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 4,
+	MinBackoff:  200 * time.Millisecond,
+	MaxBackoff:  5 * time.Second,
+}
+
+// WithRetryPolicy is option function for setting retry policy
+func WithRetryPolicy(p RetryPolicy) OptFunc {
+	return func(c *DNS1Cloud) {
+		c.retryPolicy = &p
+	}
+}
+
+var (
+	jitterMu   sync.Mutex
+	jitterRand = rand.New(rand.NewSource(time.Now().UnixNano()))
+)
+
+// backoff returns jittered exponential delay before the next attempt
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.MinBackoff
+	for i := 1; i < attempt && (p.MaxBackoff <= 0 || d < p.MaxBackoff); i++ {
+		if d > math.MaxInt64/2 {
+			d = math.MaxInt64
+			break
+		}
+		d *= 2
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	if d <= 0 {
+		return 0
+	}
+
+	jitterMu.Lock()
+	j := time.Duration(jitterRand.Int63n(int64(d)/2 + 1))
+	jitterMu.Unlock()
+
+	return d/2 + j
+}
+
+// next reports whether the command should be retried after the attempt
+// and how long to wait before that
+func (p *RetryPolicy) next(cmd command, attempt int, retryable bool, err error) (time.Duration, bool) {
+	if p == nil || !retryable || attempt >= p.MaxAttempts || !isIdempotent(cmd.method) {
+		return 0, false
+	}
+
+	if apiErr, ok := AsAPIError(err); ok && apiErr.retryAfter > 0 {
+		d := apiErr.retryAfter
+		if p.MaxBackoff > 0 && d > p.MaxBackoff {
+			d = p.MaxBackoff
+		}
+		return d, true
+	}
+	return p.backoff(attempt), true
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+func isRetryableStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+}
+
+// parseRetryAfter parses value of Retry-After header, which is either
+// a number of seconds or HTTP date
+func parseRetryAfter(v string) time.Duration {
+	if len(v) == 0 {
+		return 0
+	}
+	if secs, err := strconv.ParseUint(v, 10, 32); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,162 @@
+package dns1cloud
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	MinBackoff:  time.Millisecond,
+	MaxBackoff:  5 * time.Millisecond,
+}
+
+func TestDNS1Cloud_Retry(t *testing.T) {
+	testCases := []struct {
+		name         string
+		failures     int32
+		failStatus   int
+		retryAfter   string
+		call         func(c *DNS1Cloud) error
+		expAttempts  int32
+		expErrString string
+	}{
+		{
+			name:        "success after server errors",
+			failures:    2,
+			failStatus:  http.StatusServiceUnavailable,
+			call:        func(c *DNS1Cloud) error { _, err := c.GetDomain(context.Background(), 1); return err },
+			expAttempts: 3,
+		},
+		{
+			name:        "success after rate limit with retry-after",
+			failures:    1,
+			failStatus:  http.StatusTooManyRequests,
+			retryAfter:  "0",
+			call:        func(c *DNS1Cloud) error { _, err := c.GetRecord(context.Background(), 1); return err },
+			expAttempts: 2,
+		},
+		{
+			name:        "retry-after is capped by max backoff",
+			failures:    1,
+			failStatus:  http.StatusServiceUnavailable,
+			retryAfter:  "3600",
+			call:        func(c *DNS1Cloud) error { _, err := c.GetDomain(context.Background(), 1); return err },
+			expAttempts: 2,
+		},
+		{
+			name:         "attempts are exhausted",
+			failures:     5,
+			failStatus:   http.StatusInternalServerError,
+			call:         func(c *DNS1Cloud) error { return c.DeleteRecord(context.Background(), 1, 2) },
+			expAttempts:  3,
+			expErrString: "could not send command delete_record: bad response, status: 500, body: 'fail'",
+		},
+		{
+			name:         "client error is not retried",
+			failures:     1,
+			failStatus:   http.StatusNotFound,
+			call:         func(c *DNS1Cloud) error { _, err := c.GetDomain(context.Background(), 1); return err },
+			expAttempts:  1,
+			expErrString: "could not send command get_domain: bad response, status: 404, body: 'fail'",
+		},
+		{
+			name:       "post is not retried",
+			failures:   1,
+			failStatus: http.StatusServiceUnavailable,
+			call: func(c *DNS1Cloud) error {
+				_, err := c.AddRecord(context.Background(), 1, Record{TypeRecord: RecordTypeTXT, HostName: "@", Text: "text"})
+				return err
+			},
+			expAttempts:  1,
+			expErrString: "bad response, status: 503, body: 'fail'",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var attempts int32
+			s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if atomic.AddInt32(&attempts, 1) <= tc.failures {
+					if len(tc.retryAfter) > 0 {
+						w.Header().Set("Retry-After", tc.retryAfter)
+					}
+					w.WriteHeader(tc.failStatus)
+					w.Write([]byte("fail"))
+					return
+				}
+				w.Write([]byte("{}"))
+			}))
+			defer s.Close()
+
+			c := New("apiKey", WithApiHost(s.URL), WithRetryPolicy(testRetryPolicy))
+
+			err := tc.call(c)
+			if len(tc.expErrString) > 0 {
+				assert.EqualError(t, err, tc.expErrString)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expAttempts, atomic.LoadInt32(&attempts))
+		})
+	}
+}
+
+func TestDNS1Cloud_RetryContextCanceled(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer s.Close()
+
+	p := testRetryPolicy
+	p.MaxBackoff = time.Minute
+	c := New("apiKey", WithApiHost(s.URL), WithRetryPolicy(p))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := c.List(ctx)
+	assert.EqualError(t, err, "could not send command list: could not wait for retry after error: bad response, status: 429, body: '': context deadline exceeded")
+	assert.True(t, time.Since(start) < time.Second)
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	p := RetryPolicy{MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	for attempt, max := range []time.Duration{100, 200, 400, 800, 1000, 1000} {
+		max *= time.Millisecond
+		d := p.backoff(attempt + 1)
+		assert.True(t, d >= max/2 && d <= max, "attempt %d: %s", attempt+1, d)
+	}
+}
+
+func TestRetryPolicy_backoffWithoutLimit(t *testing.T) {
+	p := RetryPolicy{MinBackoff: 100 * time.Millisecond}
+
+	for attempt, max := range []time.Duration{100, 200, 400, 800, 1600, 3200} {
+		max *= time.Millisecond
+		d := p.backoff(attempt + 1)
+		assert.True(t, d >= max/2 && d <= max, "attempt %d: %s", attempt+1, d)
+	}
+
+	d := p.backoff(100)
+	assert.True(t, d >= math.MaxInt64/2, "attempt 100: %s", d)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	assert.Equal(t, time.Duration(0), parseRetryAfter(""))
+	assert.Equal(t, 3*time.Second, parseRetryAfter("3"))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("soon"))
+
+	d := parseRetryAfter(time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+	assert.True(t, d > 59*time.Minute && d <= time.Hour)
+}
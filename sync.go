@@ -0,0 +1,225 @@
+package dns1cloud
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ChangeAction is an action of a change of the plan
+type ChangeAction uint8
+
+const (
+	// ChangeCreate creates new record
+	ChangeCreate ChangeAction = iota
+	// ChangeUpdate updates attributes of existing record
+	ChangeUpdate
+	// ChangeDelete deletes existing record
+	ChangeDelete
+)
+
+// String returns name of the action
+func (a ChangeAction) String() string {
+	switch a {
+	case ChangeCreate:
+		return "create"
+	case ChangeUpdate:
+		return "update"
+	case ChangeDelete:
+		return "delete"
+	}
+	return "unknown"
+}
+
+// Change is a single change of the plan
+type Change struct {
+	Action ChangeAction
+	// Current is the existing record, it is empty for ChangeCreate
+	Current Record
+	// Desired is the record to be created or the new state of updated record,
+	// it is empty for ChangeDelete
+	Desired Record
+}
+
+// ChangeResult is a result of applying the change
+type ChangeResult struct {
+	Change Change
+	// Record is the record returned by API, in dry-run mode it is the desired record
+	Record Record
+	Err    error
+}
+
+// recordClient changes records of domain, it is implemented by DNS1Cloud and CachingClient
+type recordClient interface {
+	GetRecord(ctx context.Context, recordID uint64) (Record, error)
+	AddRecord(ctx context.Context, domainID uint64, record Record) (Record, error)
+	UpdateRecord(ctx context.Context, domainID uint64, record Record) (Record, error)
+	DeleteRecord(ctx context.Context, domainID uint64, recordID uint64) error
+}
+
+// Plan is a list of changes making the domain look like the desired list of records
+type Plan struct {
+	DomainID uint64
+	Creates  []Change
+	Updates  []Change
+	Deletes  []Change
+
+	client recordClient
+}
+
+// Empty reports whether the domain is already in the desired state
+func (p *Plan) Empty() bool {
+	return len(p.Creates)+len(p.Updates)+len(p.Deletes) == 0
+}
+
+// Changes returns all changes of the plan in order of applying
+func (p *Plan) Changes() []Change {
+	changes := make([]Change, 0, len(p.Creates)+len(p.Updates)+len(p.Deletes))
+	changes = append(changes, p.Creates...)
+	changes = append(changes, p.Updates...)
+	changes = append(changes, p.Deletes...)
+	return changes
+}
+
+// PlanSync compares the desired records with the records of the domain and
+// returns the plan of changes. Records are matched by type, name and value,
+// matched records with different TTL, priority, weight, port or flag are updated.
+// Names are compared relative to the domain, so "www" and "www.domain.com." are the same name.
+// Records of types unknown to the client are never deleted
+func (c *DNS1Cloud) PlanSync(ctx context.Context, domainID uint64, desired []Record) (*Plan, error) {
+	domain, err := c.GetDomain(ctx, domainID)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get current records")
+	}
+
+	current := make(map[recordKey][]Record)
+	for _, r := range domain.LinkedRecords {
+		if r.TypeRecord == RecordTypeUnknown {
+			continue
+		}
+		k := keyOf(r, domain.Name)
+		current[k] = append(current[k], r)
+	}
+
+	plan := &Plan{DomainID: domainID, client: c}
+	for _, d := range desired {
+		k := keyOf(d, domain.Name)
+		existing := current[k]
+		if len(existing) == 0 {
+			plan.Creates = append(plan.Creates, Change{Action: ChangeCreate, Desired: d})
+			continue
+		}
+
+		cur := existing[0]
+		current[k] = existing[1:]
+		if needsUpdate(cur, d) {
+			d.ID = cur.ID
+			plan.Updates = append(plan.Updates, Change{Action: ChangeUpdate, Current: cur, Desired: d})
+		}
+	}
+
+	// the rest of records is deleted in the order they are returned by API
+	for _, r := range domain.LinkedRecords {
+		k := keyOf(r, domain.Name)
+		for _, left := range current[k] {
+			if left.ID == r.ID {
+				plan.Deletes = append(plan.Deletes, Change{Action: ChangeDelete, Current: r})
+				break
+			}
+		}
+	}
+
+	return plan, nil
+}
+
+// Apply applies changes of the plan: creates first, then updates and deletes,
+// so a replaced record is removed only after its replacement is created.
+// It does not stop on failed change, results are returned for every change
+// and the error is returned if any change is failed.
+// In dry-run mode API is not called
+func (p *Plan) Apply(ctx context.Context, dryRun bool) ([]ChangeResult, error) {
+	changes := p.Changes()
+	results := make([]ChangeResult, len(changes))
+
+	var failed int
+	for i, ch := range changes {
+		res := ChangeResult{Change: ch}
+		switch {
+		case dryRun:
+			res.Record = ch.Desired
+		case ch.Action == ChangeCreate:
+			res.Record, res.Err = p.client.AddRecord(ctx, p.DomainID, ch.Desired)
+		case ch.Action == ChangeUpdate:
+			res.Record, res.Err = p.client.UpdateRecord(ctx, p.DomainID, ch.Desired)
+		case ch.Action == ChangeDelete:
+			res.Err = p.client.DeleteRecord(ctx, p.DomainID, ch.Current.ID)
+		}
+		if res.Err != nil {
+			failed++
+		}
+		results[i] = res
+	}
+
+	if failed > 0 {
+		return results, errors.Errorf("%d of %d changes failed", failed, len(changes))
+	}
+	return results, nil
+}
+
+// recordKey identifies record by type, name and value
+type recordKey struct {
+	typ   RecordType
+	name  string
+	value string
+}
+
+// keyOf returns key of the record, names are made relative to origin
+// unless it is empty
+func keyOf(r Record, origin string) recordKey {
+	name := func(n string) string { return RelativeName(n, origin) }
+
+	k := recordKey{typ: r.TypeRecord}
+	switch r.TypeRecord {
+	case RecordTypeA, RecordTypeAAAA:
+		k.name, k.value = name(r.HostName), strings.ToLower(r.IP)
+	case RecordTypeCNAME:
+		k.name, k.value = name(r.MnemonicName), name(r.HostName)
+	case RecordTypeMX:
+		k.name, k.value = "@", name(r.HostName)
+	case RecordTypeNS:
+		k.name, k.value = name(r.ExtHostName), name(r.HostName)
+	case RecordTypeTXT:
+		k.name, k.value = name(r.HostName), r.Text
+	case RecordTypeSRV:
+		k.name = "_" + strings.Trim(strings.ToLower(r.Service), "._") + "._" + strings.Trim(strings.ToLower(r.Proto), "._")
+		if host := name(r.HostName); host != "@" {
+			k.name += "." + host
+		}
+		k.value = name(r.Target)
+	case RecordTypeCAA:
+		k.name, k.value = name(r.HostName), strings.ToLower(r.Tag)+" "+r.Value
+	case RecordTypePTR:
+		k.name, k.value = name(r.HostName), name(r.Target)
+	default:
+		k.name, k.value = name(r.HostName), r.RawTypeRecord
+	}
+	return k
+}
+
+// needsUpdate reports whether attributes of current record differ from desired ones.
+// Zero TTL of the desired record means any TTL
+func needsUpdate(cur, desired Record) bool {
+	if desired.TTL != 0 && cur.TTL != desired.TTL {
+		return true
+	}
+	switch desired.TypeRecord {
+	case RecordTypeMX:
+		return cur.Priority != desired.Priority
+	case RecordTypeSRV:
+		return cur.Priority != desired.Priority || cur.Weight != desired.Weight || cur.Port != desired.Port
+	case RecordTypeCAA:
+		return cur.Flag != desired.Flag
+	}
+	return false
+}
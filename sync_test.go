@@ -0,0 +1,121 @@
+package dns1cloud_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/reinventer/dns1cloud"
+	"github.com/reinventer/dns1cloud/dns1cloudtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// syncRecords are records of domain with IDs 1..6
+var syncRecords = []dns1cloud.Record{
+	{TypeRecord: dns1cloud.RecordTypeA, IP: "1.1.1.1", HostName: "@", TTL: 3600},
+	{TypeRecord: dns1cloud.RecordTypeA, IP: "1.1.1.2", HostName: "www", TTL: 3600},
+	{TypeRecord: dns1cloud.RecordTypeMX, HostName: "mail.test.com", Priority: "10", TTL: 3600},
+	{TypeRecord: dns1cloud.RecordTypeTXT, HostName: "@", Text: "old", TTL: 3600},
+	{TypeRecord: dns1cloud.RecordTypeUnknown, RawTypeRecord: "NAPTR", HostName: "@", TTL: 3600},
+	{TypeRecord: dns1cloud.RecordTypeA, IP: "1.1.1.1", HostName: "@", TTL: 3600},
+}
+
+// requestLogClient returns HTTP client appending method and path of sequential requests to log
+func requestLogClient(log *[]string) *http.Client {
+	return &http.Client{
+		Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			*log = append(*log, r.Method+" "+r.URL.Path)
+			return http.DefaultTransport.RoundTrip(r)
+		}),
+	}
+}
+
+func TestDNS1Cloud_PlanSync(t *testing.T) {
+	srv := newTestServer(syncRecords...)
+	defer srv.Close()
+
+	plan, err := srv.Client().PlanSync(context.Background(), testDomainID, []dns1cloud.Record{
+		{TypeRecord: dns1cloud.RecordTypeA, HostName: "", IP: "1.1.1.1"},
+		{TypeRecord: dns1cloud.RecordTypeA, HostName: "WWW.", IP: "1.1.1.2", TTL: 600},
+		{TypeRecord: dns1cloud.RecordTypeMX, HostName: "mail.test.com.", Priority: "20"},
+		{TypeRecord: dns1cloud.RecordTypeTXT, HostName: "@", Text: "new"},
+	})
+	assert.NoError(t, err)
+	assert.False(t, plan.Empty())
+
+	assert.Equal(t, []dns1cloud.Change{
+		{Action: dns1cloud.ChangeCreate, Desired: dns1cloud.Record{TypeRecord: dns1cloud.RecordTypeTXT, HostName: "@", Text: "new"}},
+	}, plan.Creates)
+
+	if assert.Len(t, plan.Updates, 2) {
+		assert.Equal(t, uint64(2), plan.Updates[0].Current.ID)
+		assert.Equal(t, dns1cloud.Record{ID: 2, TypeRecord: dns1cloud.RecordTypeA, HostName: "WWW.", IP: "1.1.1.2", TTL: 600}, plan.Updates[0].Desired)
+		assert.Equal(t, uint64(3), plan.Updates[1].Current.ID)
+		assert.Equal(t, "20", plan.Updates[1].Desired.Priority)
+	}
+
+	// duplicated A record and TXT record are deleted, NAPTR record is kept
+	if assert.Len(t, plan.Deletes, 2) {
+		assert.Equal(t, uint64(4), plan.Deletes[0].Current.ID)
+		assert.Equal(t, uint64(6), plan.Deletes[1].Current.ID)
+	}
+}
+
+func TestPlan_Apply(t *testing.T) {
+	srv := newTestServer(syncRecords...)
+	defer srv.Close()
+	srv.InjectFault(dns1cloudtest.Fault{Method: http.MethodDelete, PathPrefix: "/dns/1/6", Status: http.StatusBadRequest})
+
+	var requests []string
+	c := srv.Client(dns1cloud.WithHTTPClient(requestLogClient(&requests)))
+
+	plan, err := c.PlanSync(context.Background(), testDomainID, []dns1cloud.Record{
+		{TypeRecord: dns1cloud.RecordTypeA, HostName: "@", IP: "1.1.1.1"},
+		{TypeRecord: dns1cloud.RecordTypeA, HostName: "www", IP: "1.1.1.2"},
+		{TypeRecord: dns1cloud.RecordTypeMX, HostName: "mail.test.com", Priority: "10", TTL: 600},
+		{TypeRecord: dns1cloud.RecordTypeTXT, HostName: "@", Text: "new"},
+	})
+	assert.NoError(t, err)
+
+	results, err := plan.Apply(context.Background(), true)
+	assert.NoError(t, err)
+	assert.Len(t, results, 4)
+	assert.Equal(t, []string{"GET /dns/1"}, requests)
+
+	results, err = plan.Apply(context.Background(), false)
+	assert.EqualError(t, err, "1 of 4 changes failed")
+	assert.Equal(t, []string{
+		"GET /dns/1",
+		"POST /dns/recordtxt",
+		"PUT /dns/recordmx/3",
+		"DELETE /dns/1/4",
+		"DELETE /dns/1/6",
+	}, requests)
+
+	if assert.Len(t, results, 4) {
+		assert.Equal(t, dns1cloud.ChangeCreate, results[0].Change.Action)
+		assert.Equal(t, uint64(7), results[0].Record.ID)
+		assert.Equal(t, "new", results[0].Record.Text)
+		assert.Equal(t, dns1cloud.ChangeUpdate, results[1].Change.Action)
+		assert.NoError(t, results[1].Err)
+		assert.Equal(t, dns1cloud.ChangeDelete, results[2].Change.Action)
+		assert.NoError(t, results[2].Err)
+		assert.EqualError(t, results[3].Err, "could not send command delete_record: bad response, status: 400, body: '"+badRequestBody+"'")
+	}
+}
+
+func TestDNS1Cloud_PlanSyncEmpty(t *testing.T) {
+	srv := newTestServer(syncRecords...)
+	defer srv.Close()
+
+	plan, err := srv.Client().PlanSync(context.Background(), testDomainID, []dns1cloud.Record{
+		{TypeRecord: dns1cloud.RecordTypeA, HostName: "@", IP: "1.1.1.1", TTL: 3600},
+		{TypeRecord: dns1cloud.RecordTypeA, HostName: "domain.com.", IP: "1.1.1.1", TTL: 3600},
+		{TypeRecord: dns1cloud.RecordTypeA, HostName: "www.Domain.com", IP: "1.1.1.2"},
+		{TypeRecord: dns1cloud.RecordTypeMX, HostName: "mail.test.com", Priority: "10"},
+		{TypeRecord: dns1cloud.RecordTypeTXT, HostName: "@", Text: "old"},
+	})
+	assert.NoError(t, err)
+	assert.True(t, plan.Empty())
+	assert.Empty(t, plan.Changes())
+}
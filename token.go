@@ -0,0 +1,129 @@
+package dns1cloud
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// TokenSource provides API key for requests
+type TokenSource interface {
+	// Token returns the current API key
+	Token(ctx context.Context) (string, error)
+	// Refresh is called when API rejects the key, it returns the new key
+	// or the same one if it could not be changed
+	Refresh(ctx context.Context) (string, error)
+}
+
+// WithTokenSource is option function for setting source of API key, it overrides the key passed to New.
+// If API responds with status 401 the key is refreshed and the command is retried once
+func WithTokenSource(ts TokenSource) OptFunc {
+	return func(c *DNS1Cloud) {
+		c.tokens = ts
+	}
+}
+
+// StaticTokenSource returns TokenSource which always returns the same key
+func StaticTokenSource(token string) TokenSource {
+	return staticTokenSource(token)
+}
+
+type staticTokenSource string
+
+func (s staticTokenSource) Token(context.Context) (string, error) {
+	return string(s), nil
+}
+
+func (s staticTokenSource) Refresh(context.Context) (string, error) {
+	return string(s), nil
+}
+
+// EnvTokenSource returns TokenSource which reads key from environment variable on every request
+func EnvTokenSource(name string) TokenSource {
+	return envTokenSource(name)
+}
+
+type envTokenSource string
+
+func (s envTokenSource) Token(context.Context) (string, error) {
+	token := strings.TrimSpace(os.Getenv(string(s)))
+	if len(token) == 0 {
+		return "", errors.Errorf("environment variable %s is not set", string(s))
+	}
+	return token, nil
+}
+
+func (s envTokenSource) Refresh(ctx context.Context) (string, error) {
+	return s.Token(ctx)
+}
+
+// FileTokenSource reads key from file and rereads it when the file is changed,
+// e.g. when the key is rotated by Vault agent. Surrounding whitespace is trimmed
+type FileTokenSource struct {
+	path string
+
+	mu      sync.Mutex
+	token   string
+	modTime time.Time
+	size    int64
+}
+
+// NewFileTokenSource creates and returns new FileTokenSource reading key from file at path
+func NewFileTokenSource(path string) *FileTokenSource {
+	return &FileTokenSource{path: path}
+}
+
+// Token implements TokenSource, the file is reread if its modification time or size is changed
+func (s *FileTokenSource) Token(context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fi, err := os.Stat(s.path)
+	if err != nil {
+		return "", errors.Wrap(err, "could not stat token file")
+	}
+	if len(s.token) > 0 && fi.ModTime().Equal(s.modTime) && fi.Size() == s.size {
+		return s.token, nil
+	}
+	return s.load(fi)
+}
+
+// Refresh implements TokenSource, the file is reread unconditionally
+func (s *FileTokenSource) Refresh(context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fi, err := os.Stat(s.path)
+	if err != nil {
+		return "", errors.Wrap(err, "could not stat token file")
+	}
+	return s.load(fi)
+}
+
+func (s *FileTokenSource) load(fi os.FileInfo) (string, error) {
+	b, err := ioutil.ReadFile(s.path)
+	if err != nil {
+		return "", errors.Wrap(err, "could not read token file")
+	}
+
+	token := strings.TrimSpace(string(b))
+	if len(token) == 0 {
+		return "", errors.Errorf("token file %s is empty", s.path)
+	}
+
+	s.token, s.modTime, s.size = token, fi.ModTime(), fi.Size()
+	return token, nil
+}
+
+// redact hides token in s, e.g. if it is echoed by API in the body of response
+func redact(s, token string) string {
+	if len(token) == 0 {
+		return s
+	}
+	return strings.Replace(s, token, redacted, -1)
+}
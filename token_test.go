@@ -0,0 +1,121 @@
+package dns1cloud
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDNS1Cloud_WithTokenSource(t *testing.T) {
+	var (
+		requests int32
+		valid    atomic.Value
+	)
+	valid.Store("new-key")
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		auth := r.Header.Get("Authorization")
+		if auth != "Bearer "+valid.Load().(string) {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"Message":"invalid token ` + strings.TrimPrefix(auth, "Bearer ") + `"}`))
+			return
+		}
+		w.Write([]byte(`{"ID":1}`))
+	}))
+	defer s.Close()
+
+	path := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, ioutil.WriteFile(path, []byte("old-key\n"), 0600))
+
+	ts := NewFileTokenSource(path)
+	c := New("", WithApiHost(s.URL), WithTokenSource(ts))
+
+	// the key is rotated, the client retries with the new one
+	_, err := ts.Token(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(path, []byte("new-key\n"), 0600))
+	// keep modification time and size, so only Refresh rereads the file
+	require.NoError(t, os.Chtimes(path, time.Time{}, ts.modTime))
+
+	_, err = c.GetDomain(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+
+	// the key is rejected again, refresh does not change it, the request is not repeated
+	atomic.StoreInt32(&requests, 0)
+	valid.Store("other-key")
+
+	_, err = c.AddDomain(context.Background(), "domain.com")
+	require.Error(t, err)
+	assert.True(t, IsUnauthorized(err))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+	assert.NotContains(t, err.Error(), "new-key")
+	assert.Contains(t, err.Error(), "invalid token REDACTED")
+}
+
+func TestEnvTokenSource(t *testing.T) {
+	const name = "DNS1CLOUD_TEST_TOKEN"
+	defer os.Unsetenv(name)
+
+	ts := EnvTokenSource(name)
+
+	os.Unsetenv(name)
+	_, err := ts.Token(context.Background())
+	assert.EqualError(t, err, "environment variable DNS1CLOUD_TEST_TOKEN is not set")
+
+	os.Setenv(name, " key1 ")
+	token, err := ts.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "key1", token)
+
+	os.Setenv(name, "key2")
+	token, err = ts.Refresh(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "key2", token)
+}
+
+func TestFileTokenSource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	ts := NewFileTokenSource(path)
+
+	_, err := ts.Token(context.Background())
+	assert.Error(t, err)
+
+	require.NoError(t, ioutil.WriteFile(path, []byte("  \n"), 0600))
+	_, err = ts.Token(context.Background())
+	assert.EqualError(t, err, "token file "+path+" is empty")
+
+	require.NoError(t, ioutil.WriteFile(path, []byte("key1\n"), 0600))
+	token, err := ts.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "key1", token)
+
+	// the file is changed
+	require.NoError(t, ioutil.WriteFile(path, []byte("key22\n"), 0600))
+	token, err = ts.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "key22", token)
+}
+
+func TestStaticTokenSource(t *testing.T) {
+	ts := StaticTokenSource("key")
+
+	token, err := ts.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "key", token)
+
+	token, err = ts.Refresh(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "key", token)
+}
@@ -0,0 +1,86 @@
+package dns1cloud
+
+import (
+	"context"
+	"net/http"
+)
+
+// attribute keys of spans
+const (
+	attrDomainID   = "dns1cloud.domain_id"
+	attrRecordID   = "dns1cloud.record_id"
+	attrRecordType = "dns1cloud.record_type"
+	attrHTTPStatus = "http.response.status_code"
+)
+
+// Attribute is a key-value attribute of span
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// Span is a span of trace, it may be backed by OpenTelemetry or any other tracing library
+type Span interface {
+	SetAttributes(attrs ...Attribute)
+	RecordError(err error)
+	End()
+}
+
+// Tracer starts spans and propagates trace context into outgoing requests
+type Tracer interface {
+	// Start starts span with name and returns context carrying it
+	Start(ctx context.Context, name string) (context.Context, Span)
+	// Inject writes trace context of ctx into headers of outgoing request
+	Inject(ctx context.Context, header http.Header)
+}
+
+// WithTracer is option function for setting tracer. Every public method which calls API
+// starts span named "dns1cloud.<command>", e.g. "dns1cloud.get_domain", with attributes
+// dns1cloud.domain_id, dns1cloud.record_id, dns1cloud.record_type and http.response.status_code
+func WithTracer(t Tracer) OptFunc {
+	return func(c *DNS1Cloud) {
+		c.tracer = t
+	}
+}
+
+type spanKey struct{}
+
+// span wraps Span of the tracer, nil span does nothing
+type span struct {
+	s Span
+}
+
+func (c *DNS1Cloud) startSpan(ctx context.Context, name string, attrs ...Attribute) (context.Context, *span) {
+	if c.tracer == nil {
+		return ctx, nil
+	}
+
+	ctx, s := c.tracer.Start(ctx, "dns1cloud."+name)
+	if len(attrs) > 0 {
+		s.SetAttributes(attrs...)
+	}
+	sp := &span{s: s}
+	return context.WithValue(ctx, spanKey{}, sp), sp
+}
+
+func spanFromContext(ctx context.Context) *span {
+	sp, _ := ctx.Value(spanKey{}).(*span)
+	return sp
+}
+
+func (sp *span) setAttributes(attrs ...Attribute) {
+	if sp != nil {
+		sp.s.SetAttributes(attrs...)
+	}
+}
+
+// end records err if it is not nil and ends the span
+func (sp *span) end(err error) {
+	if sp == nil {
+		return
+	}
+	if err != nil {
+		sp.s.RecordError(err)
+	}
+	sp.s.End()
+}
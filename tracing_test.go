@@ -0,0 +1,127 @@
+package dns1cloud
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testSpan struct {
+	name  string
+	attrs map[string]interface{}
+	err   error
+	ended bool
+}
+
+func (s *testSpan) SetAttributes(attrs ...Attribute) {
+	for _, a := range attrs {
+		s.attrs[a.Key] = a.Value
+	}
+}
+
+func (s *testSpan) RecordError(err error) {
+	s.err = err
+}
+
+func (s *testSpan) End() {
+	s.ended = true
+}
+
+type testSpanKey struct{}
+
+type testTracer struct {
+	mu    sync.Mutex
+	spans []*testSpan
+}
+
+func (t *testTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	s := &testSpan{name: name, attrs: map[string]interface{}{}}
+	t.mu.Lock()
+	t.spans = append(t.spans, s)
+	t.mu.Unlock()
+	return context.WithValue(ctx, testSpanKey{}, s), s
+}
+
+func (t *testTracer) Inject(ctx context.Context, header http.Header) {
+	if s, ok := ctx.Value(testSpanKey{}).(*testSpan); ok {
+		header.Set("Traceparent", s.name)
+	}
+}
+
+func TestDNS1Cloud_WithTracer(t *testing.T) {
+	var traceparents []string
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparents = append(traceparents, r.Header.Get("Traceparent"))
+		switch r.URL.Path {
+		case "/dns/recorda":
+			w.Write([]byte(`{"ID":10}`))
+		case "/dns/1":
+			w.Write([]byte(`{"ID":1}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer s.Close()
+
+	tracer := &testTracer{}
+	c := New("apiKey", WithApiHost(s.URL), WithTracer(tracer))
+
+	_, err := c.GetDomain(context.Background(), 1)
+	require.NoError(t, err)
+	_, err = c.AddRecord(context.Background(), 1, Record{TypeRecord: RecordTypeA, IP: "1.1.1.1", HostName: "@"})
+	require.NoError(t, err)
+	err = c.DeleteRecord(context.Background(), 1, 20)
+	require.Error(t, err)
+	_, err = c.UpdateRecord(context.Background(), 1, Record{TypeRecord: RecordTypeUnknown, RawTypeRecord: "HINFO"})
+	require.Error(t, err)
+
+	assert.Equal(t, []string{"dns1cloud.get_domain", "dns1cloud.add_record", "dns1cloud.delete_record"}, traceparents)
+
+	require.Len(t, tracer.spans, 4)
+	for _, sp := range tracer.spans {
+		assert.True(t, sp.ended, sp.name)
+	}
+
+	assert.Equal(t, "dns1cloud.get_domain", tracer.spans[0].name)
+	assert.Equal(t, map[string]interface{}{
+		attrDomainID:   uint64(1),
+		attrHTTPStatus: http.StatusOK,
+	}, tracer.spans[0].attrs)
+	assert.NoError(t, tracer.spans[0].err)
+
+	assert.Equal(t, "dns1cloud.add_record", tracer.spans[1].name)
+	assert.Equal(t, map[string]interface{}{
+		attrDomainID:   uint64(1),
+		attrRecordID:   uint64(10),
+		attrRecordType: "A",
+		attrHTTPStatus: http.StatusOK,
+	}, tracer.spans[1].attrs)
+
+	assert.Equal(t, "dns1cloud.delete_record", tracer.spans[2].name)
+	assert.Equal(t, map[string]interface{}{
+		attrDomainID:   uint64(1),
+		attrRecordID:   uint64(20),
+		attrHTTPStatus: http.StatusNotFound,
+	}, tracer.spans[2].attrs)
+	assert.True(t, IsNotFound(tracer.spans[2].err))
+
+	assert.Equal(t, "dns1cloud.update_record", tracer.spans[3].name)
+	assert.True(t, IsUnknownRecordType(tracer.spans[3].err))
+}
+
+func TestDNS1Cloud_WithoutTracer(t *testing.T) {
+	c := New("apiKey")
+
+	ctx, sp := c.startSpan(context.Background(), "list")
+	assert.Nil(t, sp)
+	assert.Nil(t, spanFromContext(ctx))
+
+	// nil span is a no-op
+	sp.setAttributes(Attribute{Key: attrHTTPStatus, Value: http.StatusOK})
+	sp.end(assert.AnError)
+}
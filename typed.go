@@ -0,0 +1,226 @@
+package dns1cloud
+
+import (
+	"net/netip"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// MX is typed value of MX record
+type MX struct {
+	Priority uint16
+	Target   string
+}
+
+// SRV is typed value of SRV record, Name is the host name which the service belongs to
+type SRV struct {
+	Service  string
+	Proto    string
+	Name     string
+	Priority uint16
+	Weight   uint16
+	Port     uint16
+	Target   string
+}
+
+// CAA is typed value of CAA record
+type CAA struct {
+	Flag  uint8
+	Tag   string
+	Value string
+}
+
+// NewARecord returns A record of host name pointing at IPv4 address.
+// IPv4-mapped IPv6 address is unmapped. The address is not checked,
+// callers must call Validate if it may be invalid or not IPv4
+func NewARecord(name string, addr netip.Addr, ttl uint32) Record {
+	return Record{
+		TypeRecord: RecordTypeA,
+		HostName:   name,
+		IP:         addr.Unmap().String(),
+		TTL:        ttl,
+	}
+}
+
+// NewAAAARecord returns AAAA record of host name pointing at IPv6 address.
+// The address is not checked, callers must call Validate if it may be invalid or not IPv6
+func NewAAAARecord(name string, addr netip.Addr, ttl uint32) Record {
+	return Record{
+		TypeRecord: RecordTypeAAAA,
+		HostName:   name,
+		IP:         addr.String(),
+		TTL:        ttl,
+	}
+}
+
+// NewCNAMERecord returns CNAME record making alias point at target
+func NewCNAMERecord(alias, target string, ttl uint32) Record {
+	return Record{
+		TypeRecord:   RecordTypeCNAME,
+		MnemonicName: alias,
+		HostName:     target,
+		TTL:          ttl,
+	}
+}
+
+// NewMXRecord returns MX record of the domain
+func NewMXRecord(mx MX, ttl uint32) Record {
+	return Record{
+		TypeRecord: RecordTypeMX,
+		HostName:   mx.Target,
+		Priority:   formatUint(uint64(mx.Priority)),
+		TTL:        ttl,
+	}
+}
+
+// NewNSRecord returns NS record delegating name to name server target
+func NewNSRecord(name, target string, ttl uint32) Record {
+	return Record{
+		TypeRecord:  RecordTypeNS,
+		ExtHostName: name,
+		HostName:    target,
+		TTL:         ttl,
+	}
+}
+
+// NewSRVRecord returns SRV record
+func NewSRVRecord(srv SRV, ttl uint32) Record {
+	return Record{
+		TypeRecord: RecordTypeSRV,
+		Service:    srv.Service,
+		Proto:      srv.Proto,
+		HostName:   srv.Name,
+		Priority:   formatUint(uint64(srv.Priority)),
+		Weight:     formatUint(uint64(srv.Weight)),
+		Port:       formatUint(uint64(srv.Port)),
+		Target:     srv.Target,
+		TTL:        ttl,
+	}
+}
+
+// NewTXTRecord returns TXT record of host name
+func NewTXTRecord(name, text string, ttl uint32) Record {
+	return Record{
+		TypeRecord: RecordTypeTXT,
+		HostName:   name,
+		Text:       text,
+		TTL:        ttl,
+	}
+}
+
+// NewCAARecord returns CAA record of host name
+func NewCAARecord(name string, caa CAA, ttl uint32) Record {
+	return Record{
+		TypeRecord: RecordTypeCAA,
+		HostName:   name,
+		Flag:       formatUint(uint64(caa.Flag)),
+		Tag:        caa.Tag,
+		Value:      caa.Value,
+		TTL:        ttl,
+	}
+}
+
+// NewPTRRecord returns PTR record of name pointing at target
+func NewPTRRecord(name, target string, ttl uint32) Record {
+	return Record{
+		TypeRecord: RecordTypePTR,
+		HostName:   name,
+		Target:     target,
+		TTL:        ttl,
+	}
+}
+
+// Addr returns address of A or AAAA record
+func (r Record) Addr() (netip.Addr, error) {
+	if err := r.checkType(RecordTypeA, RecordTypeAAAA); err != nil {
+		return netip.Addr{}, err
+	}
+
+	addr, err := netip.ParseAddr(r.IP)
+	if err != nil {
+		return netip.Addr{}, errors.Wrapf(err, "IP %q is incorrect", r.IP)
+	}
+	if r.TypeRecord == RecordTypeA {
+		addr = addr.Unmap()
+	}
+	return addr, nil
+}
+
+// MX returns typed value of MX record
+func (r Record) MX() (MX, error) {
+	if err := r.checkType(RecordTypeMX); err != nil {
+		return MX{}, err
+	}
+
+	priority, err := parseUint16("Priority", r.Priority)
+	if err != nil {
+		return MX{}, err
+	}
+	return MX{Priority: priority, Target: r.HostName}, nil
+}
+
+// SRV returns typed value of SRV record
+func (r Record) SRV() (SRV, error) {
+	if err := r.checkType(RecordTypeSRV); err != nil {
+		return SRV{}, err
+	}
+
+	srv := SRV{
+		Service: r.Service,
+		Proto:   r.Proto,
+		Name:    r.HostName,
+		Target:  r.Target,
+	}
+
+	var err error
+	if srv.Priority, err = parseUint16("Priority", r.Priority); err != nil {
+		return SRV{}, err
+	}
+	if srv.Weight, err = parseUint16("Weight", r.Weight); err != nil {
+		return SRV{}, err
+	}
+	if srv.Port, err = parseUint16("Port", r.Port); err != nil {
+		return SRV{}, err
+	}
+	return srv, nil
+}
+
+// CAA returns typed value of CAA record
+func (r Record) CAA() (CAA, error) {
+	if err := r.checkType(RecordTypeCAA); err != nil {
+		return CAA{}, err
+	}
+
+	flag, err := strconv.ParseUint(r.Flag, 10, 8)
+	if err != nil {
+		return CAA{}, errors.Errorf("Flag %q is not a number in range 0-255", r.Flag)
+	}
+	return CAA{Flag: uint8(flag), Tag: r.Tag, Value: r.Value}, nil
+}
+
+func (r Record) checkType(types ...RecordType) error {
+	for _, t := range types {
+		if r.TypeRecord == t {
+			return nil
+		}
+	}
+	names := make([]string, len(types))
+	for i, t := range types {
+		names[i] = t.String()
+	}
+	return errors.Errorf("record has type %s, not %s", r.TypeRecord, strings.Join(names, " or "))
+}
+
+func parseUint16(field, value string) (uint16, error) {
+	n, err := strconv.ParseUint(value, 10, 16)
+	if err != nil {
+		return 0, errors.Errorf("%s %q is not a number in range 0-65535", field, value)
+	}
+	return uint16(n), nil
+}
+
+func formatUint(n uint64) string {
+	return strconv.FormatUint(n, 10)
+}
@@ -0,0 +1,145 @@
+package dns1cloud
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRecords(t *testing.T) {
+	testCases := []struct {
+		name   string
+		record Record
+		exp    Record
+	}{
+		{
+			name:   "A",
+			record: NewARecord("www", netip.MustParseAddr("::ffff:1.2.3.4"), 300),
+			exp:    Record{TypeRecord: RecordTypeA, HostName: "www", IP: "1.2.3.4", TTL: 300},
+		},
+		{
+			name:   "AAAA",
+			record: NewAAAARecord("www", netip.MustParseAddr("2001:db8::68"), 300),
+			exp:    Record{TypeRecord: RecordTypeAAAA, HostName: "www", IP: "2001:db8::68", TTL: 300},
+		},
+		{
+			name:   "CNAME",
+			record: NewCNAMERecord("ftp", "www.domain.com", 0),
+			exp:    Record{TypeRecord: RecordTypeCNAME, MnemonicName: "ftp", HostName: "www.domain.com"},
+		},
+		{
+			name:   "MX",
+			record: NewMXRecord(MX{Priority: 10, Target: "mail.domain.com"}, 3600),
+			exp:    Record{TypeRecord: RecordTypeMX, HostName: "mail.domain.com", Priority: "10", TTL: 3600},
+		},
+		{
+			name:   "NS",
+			record: NewNSRecord("sub", "ns1.domain.com", 3600),
+			exp:    Record{TypeRecord: RecordTypeNS, ExtHostName: "sub", HostName: "ns1.domain.com", TTL: 3600},
+		},
+		{
+			name: "SRV",
+			record: NewSRVRecord(SRV{
+				Service: "_sip", Proto: "_tcp", Name: "@", Priority: 10, Weight: 20, Port: 5060, Target: "sip.domain.com",
+			}, 600),
+			exp: Record{
+				TypeRecord: RecordTypeSRV, Service: "_sip", Proto: "_tcp", HostName: "@",
+				Priority: "10", Weight: "20", Port: "5060", Target: "sip.domain.com", TTL: 600,
+			},
+		},
+		{
+			name:   "TXT",
+			record: NewTXTRecord("_dmarc", "v=DMARC1", 300),
+			exp:    Record{TypeRecord: RecordTypeTXT, HostName: "_dmarc", Text: "v=DMARC1", TTL: 300},
+		},
+		{
+			name:   "CAA",
+			record: NewCAARecord("@", CAA{Flag: 128, Tag: "issue", Value: "letsencrypt.org"}, 300),
+			exp:    Record{TypeRecord: RecordTypeCAA, HostName: "@", Flag: "128", Tag: "issue", Value: "letsencrypt.org", TTL: 300},
+		},
+		{
+			name:   "PTR",
+			record: NewPTRRecord("10", "host.domain.com", 300),
+			exp:    Record{TypeRecord: RecordTypePTR, HostName: "10", Target: "host.domain.com", TTL: 300},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.exp, tc.record)
+			assert.NoError(t, tc.record.Validate())
+		})
+	}
+}
+
+func TestNewAddrRecords_validate(t *testing.T) {
+	assert.EqualError(t, NewARecord("www", netip.MustParseAddr("2001:db8::68"), 0).Validate(),
+		`IP "2001:db8::68" is not IPv4 address`)
+	assert.EqualError(t, NewAAAARecord("www", netip.MustParseAddr("1.2.3.4"), 0).Validate(),
+		`IP "1.2.3.4" is not IPv6 address`)
+	assert.EqualError(t, NewARecord("www", netip.Addr{}, 0).Validate(), `IP "invalid IP" is incorrect`)
+}
+
+func TestNewSRVRecord_wireFormat(t *testing.T) {
+	record := NewSRVRecord(SRV{
+		Service: "service", Proto: "tcp", Name: "name.test.com", Priority: 10, Weight: 30, Port: 4321, Target: "service.test.com",
+	}, 3600)
+
+	cmd, err := makeAddRecordSRVCommand(123, record)
+	require.NoError(t, err)
+	assert.Equal(t, &addRecordSRVParams{
+		DomainID: "123",
+		Service:  "service",
+		Proto:    "tcp",
+		Name:     "name.test.com",
+		Priority: "10",
+		Weight:   "30",
+		Port:     "4321",
+		Target:   "service.test.com",
+		TTL:      "3600",
+	}, cmd.params)
+}
+
+func TestRecord_typedViews(t *testing.T) {
+	addr, err := Record{TypeRecord: RecordTypeA, IP: "1.2.3.4"}.Addr()
+	require.NoError(t, err)
+	assert.Equal(t, netip.MustParseAddr("1.2.3.4"), addr)
+
+	addr, err = Record{TypeRecord: RecordTypeAAAA, IP: "2001:db8::68"}.Addr()
+	require.NoError(t, err)
+	assert.Equal(t, netip.MustParseAddr("2001:db8::68"), addr)
+
+	_, err = Record{TypeRecord: RecordTypeA, IP: "ip"}.Addr()
+	assert.EqualError(t, err, `IP "ip" is incorrect: ParseAddr("ip"): unable to parse IP`)
+
+	_, err = Record{TypeRecord: RecordTypeTXT}.Addr()
+	assert.EqualError(t, err, "record has type TXT, not A or AAAA")
+
+	mx, err := Record{TypeRecord: RecordTypeMX, HostName: "mail.domain.com", Priority: "10"}.MX()
+	require.NoError(t, err)
+	assert.Equal(t, MX{Priority: 10, Target: "mail.domain.com"}, mx)
+
+	_, err = Record{TypeRecord: RecordTypeMX, Priority: "high"}.MX()
+	assert.EqualError(t, err, `Priority "high" is not a number in range 0-65535`)
+
+	srv := SRV{Service: "_sip", Proto: "_udp", Name: "@", Priority: 1, Weight: 2, Port: 3, Target: "sip.domain.com"}
+	got, err := NewSRVRecord(srv, 0).SRV()
+	require.NoError(t, err)
+	assert.Equal(t, srv, got)
+
+	_, err = Record{TypeRecord: RecordTypeSRV, Priority: "1", Weight: "2", Port: "70000"}.SRV()
+	assert.EqualError(t, err, `Port "70000" is not a number in range 0-65535`)
+
+	_, err = Record{TypeRecord: RecordTypeA}.SRV()
+	assert.EqualError(t, err, "record has type A, not SRV")
+
+	caa := CAA{Flag: 0, Tag: "iodef", Value: "mailto:admin@domain.com"}
+	gotCAA, err := NewCAARecord("@", caa, 0).CAA()
+	require.NoError(t, err)
+	assert.Equal(t, caa, gotCAA)
+
+	_, err = Record{TypeRecord: RecordTypeCAA, Flag: "256"}.CAA()
+	assert.EqualError(t, err, `Flag "256" is not a number in range 0-255`)
+}
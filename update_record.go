@@ -15,12 +15,19 @@ func (c *DNS1Cloud) UpdateRecord(
 	ctx context.Context,
 	domainID uint64,
 	record Record,
-) (Record, error) {
-	var (
-		res Record
-		cmd command
-		err error
+) (res Record, err error) {
+	ctx, sp := c.startSpan(ctx, "update_record",
+		Attribute{Key: attrDomainID, Value: domainID},
+		Attribute{Key: attrRecordID, Value: record.ID},
+		Attribute{Key: attrRecordType, Value: record.TypeRecord.String()},
 	)
+	defer func() { sp.end(err) }()
+
+	if err = record.Validate(); err != nil {
+		return res, err
+	}
+
+	var cmd command
 
 	switch record.TypeRecord {
 	case RecordTypeA:
@@ -37,13 +44,18 @@ func (c *DNS1Cloud) UpdateRecord(
 		cmd, err = makeUpdateRecordSRVCommand(domainID, record)
 	case RecordTypeTXT:
 		cmd, err = makeUpdateRecordTXTCommand(domainID, record)
+	case RecordTypeCAA:
+		cmd, err = makeUpdateRecordCAACommand(domainID, record)
+	case RecordTypePTR:
+		cmd, err = makeUpdateRecordPTRCommand(domainID, record)
 	default:
-		err = errors.Errorf("unknown record type: %d", record.TypeRecord)
+		err = &UnknownRecordTypeError{Type: record.TypeRecord, Raw: record.RawTypeRecord}
 	}
 	if err != nil {
 		return res, err
 	}
 
+	cmd.name = "update_record"
 	err = c.send(ctx, cmd, &res)
 	return res, err
 }
@@ -101,6 +113,24 @@ type updateRecordTXTParams struct {
 	TTL      string `json:"TTL,omitempty"`
 }
 
+// updateRecordCAAParams parameters for request for updating CAA records
+type updateRecordCAAParams struct {
+	DomainID string `json:"DomainId"`
+	Name     string `json:"Name"`
+	Flag     string `json:"Flag"`
+	Tag      string `json:"Tag"`
+	Value    string `json:"Value"`
+	TTL      string `json:"TTL,omitempty"`
+}
+
+// updateRecordPTRParams parameters for request for updating PTR records
+type updateRecordPTRParams struct {
+	DomainID string `json:"DomainId"`
+	Name     string `json:"Name"`
+	Target   string `json:"Target"`
+	TTL      string `json:"TTL,omitempty"`
+}
+
 func makeUpdateRecordACommand(domainID uint64, record Record) (command, error) {
 	ttl, err := getTTL(record.TTL)
 	if err != nil {
@@ -255,3 +285,45 @@ func makeUpdateRecordTXTCommand(domainID uint64, record Record) (command, error)
 		params:   &params,
 	}, nil
 }
+
+func makeUpdateRecordCAACommand(domainID uint64, record Record) (command, error) {
+	ttl, err := getTTL(record.TTL)
+	if err != nil {
+		return command{}, err
+	}
+
+	params := updateRecordCAAParams{
+		DomainID: strconv.FormatUint(domainID, 10),
+		Name:     record.HostName,
+		Flag:     record.Flag,
+		Tag:      record.Tag,
+		Value:    record.Value,
+		TTL:      ttl,
+	}
+
+	return command{
+		method:   http.MethodPut,
+		endpoint: fmt.Sprintf("dns/recordcaa/%d", record.ID),
+		params:   &params,
+	}, nil
+}
+
+func makeUpdateRecordPTRCommand(domainID uint64, record Record) (command, error) {
+	ttl, err := getTTL(record.TTL)
+	if err != nil {
+		return command{}, err
+	}
+
+	params := updateRecordPTRParams{
+		DomainID: strconv.FormatUint(domainID, 10),
+		Name:     record.HostName,
+		Target:   record.Target,
+		TTL:      ttl,
+	}
+
+	return command{
+		method:   http.MethodPut,
+		endpoint: fmt.Sprintf("dns/recordptr/%d", record.ID),
+		params:   &params,
+	}, nil
+}
@@ -253,12 +253,69 @@ func TestDNS1Cloud_UpdateRecord(t *testing.T) {
 			expRecord:    Record{},
 			expErrString: `TTL "7" is not valid`,
 		},
+		{
+			name:           "success update record CAA",
+			reqRecord:      Record{ID: 1, TypeRecord: RecordTypeCAA, HostName: "@", Flag: "0", Tag: "issue", Value: "letsencrypt.org", TTL: 3600},
+			responseStatus: http.StatusOK,
+			responseJSON: `{"ID": 1, "TypeRecord": "CAA", "IP": "", "HostName": "@", "Text": "", "Flag": "0", "Tag": "issue",
+				"Value": "letsencrypt.org", "State": "Active", "TTL": 3600,
+				"CanonicalDescription": "@ 3600 IN CAA 0 issue \"letsencrypt.org\""}`,
+			expPath:    "/dns/recordcaa/1",
+			expRequest: `{"DomainId": "123", "Name": "@", "Flag": "0", "Tag": "issue", "Value": "letsencrypt.org", "TTL": "3600"}`,
+			expRecord: Record{
+				ID:                   1,
+				TypeRecord:           RecordTypeCAA,
+				HostName:             "@",
+				Flag:                 "0",
+				Tag:                  "issue",
+				Value:                "letsencrypt.org",
+				State:                StateActive,
+				TTL:                  3600,
+				CanonicalDescription: `@ 3600 IN CAA 0 issue "letsencrypt.org"`,
+			},
+		},
+		{
+			name:         "incorrect ttl for record CAA",
+			reqRecord:    Record{ID: 1, TypeRecord: RecordTypeCAA, HostName: "@", Flag: "0", Tag: "issue", Value: "letsencrypt.org", TTL: 7},
+			expRecord:    Record{},
+			expErrString: `TTL "7" is not valid`,
+		},
+		{
+			name:           "success update record PTR",
+			reqRecord:      Record{ID: 1, TypeRecord: RecordTypePTR, HostName: "10", Target: "host.test.com.", TTL: 3600},
+			responseStatus: http.StatusOK,
+			responseJSON: `{"ID": 1, "TypeRecord": "PTR", "IP": "", "HostName": "10", "Target": "host.test.com.",
+				"State": "Active", "TTL": 3600, "CanonicalDescription": "10 3600 IN PTR host.test.com."}`,
+			expPath:    "/dns/recordptr/1",
+			expRequest: `{"DomainId": "123", "Name": "10", "Target": "host.test.com.", "TTL": "3600"}`,
+			expRecord: Record{
+				ID:                   1,
+				TypeRecord:           RecordTypePTR,
+				HostName:             "10",
+				Target:               "host.test.com.",
+				State:                StateActive,
+				TTL:                  3600,
+				CanonicalDescription: "10 3600 IN PTR host.test.com.",
+			},
+		},
+		{
+			name:         "incorrect ttl for record PTR",
+			reqRecord:    Record{ID: 1, TypeRecord: RecordTypePTR, HostName: "10", Target: "host.test.com.", TTL: 7},
+			expRecord:    Record{},
+			expErrString: `TTL "7" is not valid`,
+		},
 		{
 			name:         "fail: unknown record type",
 			reqRecord:    Record{ID: 1, TypeRecord: 100},
 			expRecord:    Record{},
 			expErrString: `unknown record type: 100`,
 		},
+		{
+			name:         "fail: unknown record type received from API",
+			reqRecord:    Record{ID: 1, TypeRecord: RecordTypeUnknown, RawTypeRecord: "NAPTR"},
+			expRecord:    Record{},
+			expErrString: `unknown record type: "NAPTR"`,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -0,0 +1,259 @@
+package dns1cloud
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	maxNameLength  = 253
+	maxLabelLength = 63
+	maxTXTChunk    = 255
+	// maxTXTLength is the maximum size of RDATA of TXT record including length bytes of chunks
+	maxTXTLength = 65535
+	maxCAATag    = 15
+)
+
+// FieldError is an invalid field of record
+type FieldError struct {
+	// Field is name of the field of Record, e.g. "Priority"
+	Field string
+	// Message describes the problem
+	Message string
+}
+
+// Error implements error interface
+func (e *FieldError) Error() string {
+	return e.Message
+}
+
+// ValidationError is returned by Record.Validate, it lists all invalid fields of the record
+type ValidationError struct {
+	Errors []*FieldError
+}
+
+// Error implements error interface
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, fe := range e.Errors {
+		msgs[i] = fe.Message
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (e *ValidationError) add(field, format string, args ...interface{}) {
+	e.Errors = append(e.Errors, &FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// IsValidationError reports whether err is caused by invalid record
+func IsValidationError(err error) bool {
+	_, ok := errors.Cause(err).(*ValidationError)
+	return ok
+}
+
+// Validate checks fields used by the type of the record before it is sent to API.
+// It returns *UnknownRecordTypeError for unsupported types and *ValidationError listing
+// all invalid fields otherwise. Names may be relative to the domain, "@" is the domain itself
+func (r Record) Validate() error {
+	v := &ValidationError{}
+
+	if r.TTL != 0 && !IsValidTTL(r.TTL) {
+		v.add("TTL", "TTL %q is not valid", strconv.FormatUint(uint64(r.TTL), 10))
+	}
+
+	switch r.TypeRecord {
+	case RecordTypeA:
+		v.ownerName("HostName", r.HostName)
+		v.ip("IP", r.IP, false)
+	case RecordTypeAAAA:
+		v.ownerName("HostName", r.HostName)
+		v.ip("IP", r.IP, true)
+	case RecordTypeCNAME:
+		v.required("MnemonicName", r.MnemonicName)
+		v.ownerName("MnemonicName", r.MnemonicName)
+		v.hostName("HostName", r.HostName)
+	case RecordTypeMX:
+		v.hostName("HostName", r.HostName)
+		v.uint16("Priority", r.Priority)
+	case RecordTypeNS:
+		v.ownerName("ExtHostName", r.ExtHostName)
+		v.hostName("HostName", r.HostName)
+	case RecordTypeSRV:
+		v.serviceLabel("Service", r.Service)
+		v.serviceLabel("Proto", r.Proto)
+		v.ownerName("HostName", r.HostName)
+		v.uint16("Priority", r.Priority)
+		v.uint16("Weight", r.Weight)
+		v.uint16("Port", r.Port)
+		if r.Target != "." {
+			v.hostName("Target", r.Target)
+		}
+	case RecordTypeTXT:
+		v.ownerName("HostName", r.HostName)
+		v.txt("Text", r.Text)
+	case RecordTypeCAA:
+		v.ownerName("HostName", r.HostName)
+		v.caaFlag("Flag", r.Flag)
+		v.caaTag("Tag", r.Tag)
+		v.required("Value", r.Value)
+	case RecordTypePTR:
+		v.ownerName("HostName", r.HostName)
+		v.hostName("Target", r.Target)
+	default:
+		return &UnknownRecordTypeError{Type: r.TypeRecord, Raw: r.RawTypeRecord}
+	}
+
+	if len(v.Errors) > 0 {
+		return v
+	}
+	return nil
+}
+
+func (v *ValidationError) required(field, value string) bool {
+	if len(value) == 0 {
+		v.add(field, "%s is required", field)
+		return false
+	}
+	return true
+}
+
+func (v *ValidationError) ip(field, value string, ipv6 bool) {
+	ip := net.ParseIP(value)
+	switch {
+	case ip == nil:
+		v.add(field, "IP %q is incorrect", value)
+	case ipv6 && !strings.Contains(value, ":"):
+		v.add(field, "IP %q is not IPv6 address", value)
+	case !ipv6 && ip.To4() == nil:
+		v.add(field, "IP %q is not IPv4 address", value)
+	}
+}
+
+func (v *ValidationError) uint16(field, value string) {
+	if !v.required(field, value) {
+		return
+	}
+	if _, err := strconv.ParseUint(value, 10, 16); err != nil {
+		v.add(field, "%s %q is not a number in range 0-65535", field, value)
+	}
+}
+
+// ownerName checks name of the record, it allows underscores, e.g. "_dmarc",
+// and wildcard as the first label
+func (v *ValidationError) ownerName(field, value string) {
+	if msg := checkName(value, true); len(msg) > 0 {
+		v.add(field, "%s %q %s", field, value, msg)
+	}
+}
+
+// hostName checks name of a host which the record points at
+func (v *ValidationError) hostName(field, value string) {
+	if !v.required(field, value) {
+		return
+	}
+	if msg := checkName(value, false); len(msg) > 0 {
+		v.add(field, "%s %q %s", field, value, msg)
+	}
+}
+
+// serviceLabel checks service or protocol of SRV record, e.g. "_sip" or "tcp"
+func (v *ValidationError) serviceLabel(field, value string) {
+	if !v.required(field, value) {
+		return
+	}
+	label := strings.TrimSuffix(strings.TrimPrefix(value, "_"), ".")
+	if strings.Contains(label, "_") {
+		v.add(field, "%s %q may contain underscore only as the first character", field, value)
+		return
+	}
+	if msg := checkLabel(label, false); len(msg) > 0 {
+		v.add(field, "%s %q %s", field, value, msg)
+	}
+}
+
+func (v *ValidationError) txt(field, value string) {
+	if !v.required(field, value) {
+		return
+	}
+	chunks := (len(value) + maxTXTChunk - 1) / maxTXTChunk
+	if len(value)+chunks > maxTXTLength {
+		v.add(field, "%s is too long: %d bytes in %d chunks of %d bytes exceed %d bytes",
+			field, len(value), chunks, maxTXTChunk, maxTXTLength)
+	}
+}
+
+func (v *ValidationError) caaFlag(field, value string) {
+	if !v.required(field, value) {
+		return
+	}
+	if _, err := strconv.ParseUint(value, 10, 8); err != nil {
+		v.add(field, "%s %q is not a number in range 0-255", field, value)
+	}
+}
+
+func (v *ValidationError) caaTag(field, value string) {
+	if !v.required(field, value) {
+		return
+	}
+	if len(value) > maxCAATag {
+		v.add(field, "%s %q is longer than %d characters", field, value, maxCAATag)
+		return
+	}
+	for _, ch := range value {
+		if !isLetterOrDigit(ch) {
+			v.add(field, "%s %q may contain only letters and digits", field, value)
+			return
+		}
+	}
+}
+
+// checkName returns description of the problem with name or empty string if name is valid.
+// Empty name, "@" and fully qualified names with trailing dot are accepted
+func checkName(name string, owner bool) string {
+	if len(name) == 0 || name == "@" {
+		return ""
+	}
+
+	name = strings.TrimSuffix(name, ".")
+	if len(name) > maxNameLength {
+		return fmt.Sprintf("is longer than %d characters", maxNameLength)
+	}
+
+	for i, label := range strings.Split(name, ".") {
+		if owner && i == 0 && label == "*" {
+			continue
+		}
+		if msg := checkLabel(label, owner); len(msg) > 0 {
+			return msg
+		}
+	}
+	return ""
+}
+
+// checkLabel checks label of name, underscores are allowed in names of records only
+func checkLabel(label string, underscore bool) string {
+	switch {
+	case len(label) == 0:
+		return "has empty label"
+	case len(label) > maxLabelLength:
+		return fmt.Sprintf("has label longer than %d characters", maxLabelLength)
+	case label[0] == '-' || label[len(label)-1] == '-':
+		return fmt.Sprintf("has label %q starting or ending with hyphen", label)
+	}
+
+	for _, ch := range label {
+		if isLetterOrDigit(ch) || ch == '-' || (underscore && ch == '_') {
+			continue
+		}
+		return fmt.Sprintf("has invalid character %q", ch)
+	}
+	return ""
+}
+
+func isLetterOrDigit(ch rune) bool {
+	return ('a' <= ch && ch <= 'z') || ('A' <= ch && ch <= 'Z') || ('0' <= ch && ch <= '9')
+}
@@ -0,0 +1,181 @@
+package dns1cloud
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecord_Validate(t *testing.T) {
+	testCases := []struct {
+		name      string
+		record    Record
+		expFields []string
+		expErr    string
+	}{
+		{
+			name:   "valid A",
+			record: Record{TypeRecord: RecordTypeA, HostName: "www", IP: "1.1.1.1", TTL: 300},
+		},
+		{
+			name:   "valid A with wildcard",
+			record: Record{TypeRecord: RecordTypeA, HostName: "*.dev", IP: "1.1.1.1"},
+		},
+		{
+			name:      "A with IPv6 address",
+			record:    Record{TypeRecord: RecordTypeA, HostName: "@", IP: "2001:db8::1"},
+			expFields: []string{"IP"},
+			expErr:    `IP "2001:db8::1" is not IPv4 address`,
+		},
+		{
+			name:      "AAAA with IPv4 address",
+			record:    Record{TypeRecord: RecordTypeAAAA, HostName: "@", IP: "1.1.1.1"},
+			expFields: []string{"IP"},
+			expErr:    `IP "1.1.1.1" is not IPv6 address`,
+		},
+		{
+			name:      "several invalid fields",
+			record:    Record{TypeRecord: RecordTypeA, HostName: "-www", IP: "ip", TTL: 7},
+			expFields: []string{"TTL", "HostName", "IP"},
+			expErr:    `TTL "7" is not valid; HostName "-www" has label "-www" starting or ending with hyphen; IP "ip" is incorrect`,
+		},
+		{
+			name:      "too long label",
+			record:    Record{TypeRecord: RecordTypeA, HostName: strings.Repeat("a", 64), IP: "1.1.1.1"},
+			expFields: []string{"HostName"},
+			expErr:    `HostName "` + strings.Repeat("a", 64) + `" has label longer than 63 characters`,
+		},
+		{
+			name:      "too long name",
+			record:    Record{TypeRecord: RecordTypeA, HostName: strings.Repeat("a.", 128), IP: "1.1.1.1"},
+			expFields: []string{"HostName"},
+			expErr:    `HostName "` + strings.Repeat("a.", 128) + `" is longer than 253 characters`,
+		},
+		{
+			name:   "valid CNAME",
+			record: Record{TypeRecord: RecordTypeCNAME, MnemonicName: "_domainkey", HostName: "dkim.example.com."},
+		},
+		{
+			name:      "CNAME target with underscore",
+			record:    Record{TypeRecord: RecordTypeCNAME, MnemonicName: "www", HostName: "my_host.com"},
+			expFields: []string{"HostName"},
+			expErr:    `HostName "my_host.com" has invalid character '_'`,
+		},
+		{
+			name:      "CNAME without alias",
+			record:    Record{TypeRecord: RecordTypeCNAME, HostName: "@"},
+			expFields: []string{"MnemonicName"},
+			expErr:    `MnemonicName is required`,
+		},
+		{
+			name:   "valid MX",
+			record: Record{TypeRecord: RecordTypeMX, HostName: "mail.example.com", Priority: "10"},
+		},
+		{
+			name:      "MX with bad priority",
+			record:    Record{TypeRecord: RecordTypeMX, HostName: "mail..example.com", Priority: "65536"},
+			expFields: []string{"HostName", "Priority"},
+			expErr:    `HostName "mail..example.com" has empty label; Priority "65536" is not a number in range 0-65535`,
+		},
+		{
+			name:      "NS without target",
+			record:    Record{TypeRecord: RecordTypeNS, ExtHostName: "sub"},
+			expFields: []string{"HostName"},
+			expErr:    `HostName is required`,
+		},
+		{
+			name: "valid SRV",
+			record: Record{
+				TypeRecord: RecordTypeSRV, Service: "_sip", Proto: "_tcp", HostName: "@",
+				Priority: "10", Weight: "0", Port: "5060", Target: "sip.example.com",
+			},
+		},
+		{
+			name: "SRV with invalid fields",
+			record: Record{
+				TypeRecord: RecordTypeSRV, Service: "s_ip", Proto: "", HostName: "@",
+				Priority: "-1", Weight: "w", Port: "70000", Target: "",
+			},
+			expFields: []string{"Service", "Proto", "Priority", "Weight", "Port", "Target"},
+			expErr: `Service "s_ip" may contain underscore only as the first character; Proto is required; ` +
+				`Priority "-1" is not a number in range 0-65535; Weight "w" is not a number in range 0-65535; ` +
+				`Port "70000" is not a number in range 0-65535; Target is required`,
+		},
+		{
+			name:   "valid long TXT",
+			record: Record{TypeRecord: RecordTypeTXT, HostName: "_dmarc", Text: strings.Repeat("a", 1000)},
+		},
+		{
+			name:      "too long TXT",
+			record:    Record{TypeRecord: RecordTypeTXT, HostName: "@", Text: strings.Repeat("a", 65280)},
+			expFields: []string{"Text"},
+			expErr:    `Text is too long: 65280 bytes in 256 chunks of 255 bytes exceed 65535 bytes`,
+		},
+		{
+			name:   "valid CAA",
+			record: Record{TypeRecord: RecordTypeCAA, HostName: "@", Flag: "128", Tag: "issue", Value: "letsencrypt.org"},
+		},
+		{
+			name:      "CAA with invalid fields",
+			record:    Record{TypeRecord: RecordTypeCAA, HostName: "@", Flag: "256", Tag: "is-sue"},
+			expFields: []string{"Flag", "Tag", "Value"},
+			expErr:    `Flag "256" is not a number in range 0-255; Tag "is-sue" may contain only letters and digits; Value is required`,
+		},
+		{
+			name:      "PTR with invalid target",
+			record:    Record{TypeRecord: RecordTypePTR, HostName: "10", Target: "host name"},
+			expFields: []string{"Target"},
+			expErr:    `Target "host name" has invalid character ' '`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.record.Validate()
+			if len(tc.expErr) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			assert.EqualError(t, err, tc.expErr)
+			assert.True(t, IsValidationError(err))
+
+			var fields []string
+			for _, fe := range err.(*ValidationError).Errors {
+				fields = append(fields, fe.Field)
+			}
+			assert.Equal(t, tc.expFields, fields)
+		})
+	}
+}
+
+func TestRecord_Validate_unknownType(t *testing.T) {
+	err := Record{TypeRecord: RecordTypeUnknown, RawTypeRecord: "NAPTR"}.Validate()
+	assert.True(t, IsUnknownRecordType(err))
+}
+
+func TestDNS1Cloud_AddRecord_validation(t *testing.T) {
+	requests := 0
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	defer s.Close()
+
+	c := New("apiKey", WithApiHost(s.URL))
+	record := Record{TypeRecord: RecordTypeMX, HostName: "mail.example.com", Priority: "high"}
+
+	_, err := c.AddRecord(context.Background(), 1, record)
+	assert.True(t, IsValidationError(err))
+
+	record.ID = 1
+	_, err = c.UpdateRecord(context.Background(), 1, record)
+	assert.True(t, IsValidationError(err))
+
+	assert.Equal(t, 0, requests)
+}
@@ -0,0 +1,129 @@
+// Package zonefile converts domains of 1Cloud's DNS hosting to and from
+// RFC 1035 master files
+package zonefile
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/reinventer/dns1cloud"
+)
+
+// DefaultTTL is TTL written to $TTL directive and used for records without TTL
+const DefaultTTL = 3600
+
+// maxStringLength is the maximum length of character string in TXT record
+const maxStringLength = 255
+
+// Write writes domain with its records as master file
+func Write(w io.Writer, domain dns1cloud.Domain) error {
+	if len(domain.Name) == 0 {
+		return errors.New("domain name is empty")
+	}
+
+	origin := dns1cloud.FQDN("@", domain.Name)
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintf(bw, "$ORIGIN %s\n", origin)
+	fmt.Fprintf(bw, "$TTL %d\n", DefaultTTL)
+
+	for _, r := range domain.LinkedRecords {
+		data, err := rdata(r, origin)
+		if err != nil {
+			return errors.Wrapf(err, "could not format record %d", r.ID)
+		}
+		if len(data) == 0 {
+			fmt.Fprintf(bw, "; record %d has unsupported type %s\n", r.ID, typeName(r))
+			continue
+		}
+
+		fmt.Fprintf(bw, "%s\t", owner(r, origin))
+		if r.TTL > 0 {
+			fmt.Fprintf(bw, "%d\t", r.TTL)
+		}
+		fmt.Fprintf(bw, "IN\t%s\t%s\n", r.TypeRecord, data)
+	}
+
+	return bw.Flush()
+}
+
+// rdata returns presentation format of record data or empty string for unsupported types
+func rdata(r dns1cloud.Record, origin string) (string, error) {
+	switch r.TypeRecord {
+	case dns1cloud.RecordTypeA, dns1cloud.RecordTypeAAAA:
+		if len(r.IP) == 0 {
+			return "", errors.New("IP is empty")
+		}
+		return r.IP, nil
+	case dns1cloud.RecordTypeCNAME:
+		return target(r.HostName, origin), nil
+	case dns1cloud.RecordTypeMX:
+		return number(r.Priority) + " " + target(r.HostName, origin), nil
+	case dns1cloud.RecordTypeNS:
+		return target(r.HostName, origin), nil
+	case dns1cloud.RecordTypeTXT:
+		return quoteTXT(r.Text), nil
+	case dns1cloud.RecordTypeSRV:
+		return strings.Join([]string{
+			number(r.Priority), number(r.Weight), number(r.Port), target(r.Target, origin),
+		}, " "), nil
+	case dns1cloud.RecordTypeCAA:
+		return number(r.Flag) + " " + r.Tag + " " + quote(r.Value), nil
+	case dns1cloud.RecordTypePTR:
+		return target(r.Target, origin), nil
+	}
+	return "", nil
+}
+
+func typeName(r dns1cloud.Record) string {
+	if r.TypeRecord == dns1cloud.RecordTypeUnknown {
+		return r.RawTypeRecord
+	}
+	return r.TypeRecord.String()
+}
+
+func number(s string) string {
+	if len(s) == 0 {
+		return "0"
+	}
+	return s
+}
+
+// quoteTXT splits text to character strings of allowed length and quotes them
+func quoteTXT(text string) string {
+	if len(text) == 0 {
+		return `""`
+	}
+
+	var parts []string
+	for len(text) > maxStringLength {
+		parts = append(parts, quote(text[:maxStringLength]))
+		text = text[maxStringLength:]
+	}
+	parts = append(parts, quote(text))
+
+	return strings.Join(parts, " ")
+}
+
+// quote quotes character string escaping quotes, backslashes and non-printable bytes
+func quote(s string) string {
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '"' || c == '\\':
+			sb.WriteByte('\\')
+			sb.WriteByte(c)
+		case c < ' ' || c > '~':
+			fmt.Fprintf(&sb, "\\%03d", c)
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	sb.WriteByte('"')
+	return sb.String()
+}
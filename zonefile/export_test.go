@@ -0,0 +1,100 @@
+package zonefile
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/reinventer/dns1cloud"
+	"github.com/stretchr/testify/assert"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+var testDomain = dns1cloud.Domain{
+	ID:   123,
+	Name: "domain.com",
+	LinkedRecords: []dns1cloud.Record{
+		{ID: 1, TypeRecord: dns1cloud.RecordTypeA, HostName: "@", IP: "1.1.1.2", TTL: 3600},
+		{ID: 2, TypeRecord: dns1cloud.RecordTypeA, HostName: "www", IP: "1.1.1.3"},
+		{ID: 3, TypeRecord: dns1cloud.RecordTypeAAAA, HostName: "www.domain.com", IP: "2001:db8::68", TTL: 600},
+		{ID: 4, TypeRecord: dns1cloud.RecordTypeCNAME, HostName: "www.domain.com", MnemonicName: "ftp", TTL: 300},
+		{ID: 5, TypeRecord: dns1cloud.RecordTypeCNAME, HostName: "@", MnemonicName: "blog"},
+		{ID: 6, TypeRecord: dns1cloud.RecordTypeMX, HostName: "mail.test.com", Priority: "10", TTL: 3600},
+		{ID: 7, TypeRecord: dns1cloud.RecordTypeNS, HostName: "ns1.test.com", ExtHostName: "sub", TTL: 86400},
+		{ID: 8, TypeRecord: dns1cloud.RecordTypeTXT, HostName: "@", Text: `v=spf1 include:_spf.test.com "quoted" \ ~all`},
+		{ID: 9, TypeRecord: dns1cloud.RecordTypeTXT, HostName: "long", Text: strings.Repeat("0123456789", 30)},
+		{
+			ID: 10, TypeRecord: dns1cloud.RecordTypeSRV, HostName: "@", Service: "_xmpp-client.", Proto: "tcp",
+			Priority: "20", Weight: "0", Port: "5222", Target: "domain-xmpp.test.com.", TTL: 21160,
+		},
+		{
+			ID: 11, TypeRecord: dns1cloud.RecordTypeSRV, HostName: "chat", Service: "sip", Proto: "_udp",
+			Priority: "10", Weight: "5", Port: "5060", Target: "sip",
+		},
+		{ID: 12, TypeRecord: dns1cloud.RecordTypeCAA, HostName: "@", Flag: "0", Tag: "issue", Value: "letsencrypt.org"},
+		{ID: 13, TypeRecord: dns1cloud.RecordTypeTXT, HostName: "bin", Text: "tab\there"},
+		{ID: 14, TypeRecord: dns1cloud.RecordTypeUnknown, RawTypeRecord: "NAPTR", HostName: "@"},
+	},
+}
+
+func TestWrite(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, Write(&buf, testDomain))
+
+	golden := filepath.Join("testdata", "domain.com.zone")
+	if *update {
+		assert.NoError(t, ioutil.WriteFile(golden, buf.Bytes(), 0644))
+	}
+
+	exp, err := ioutil.ReadFile(golden)
+	assert.NoError(t, err)
+	assert.Equal(t, string(exp), buf.String())
+}
+
+func TestWrite_RoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, Write(&buf, testDomain))
+
+	records, err := Parse(&buf, "")
+	assert.NoError(t, err)
+
+	// names come back relative to the domain, services and protocols without
+	// underscores, default TTL is set and the record of unsupported type is lost
+	assert.Equal(t, []dns1cloud.Record{
+		{TypeRecord: dns1cloud.RecordTypeA, HostName: "@", IP: "1.1.1.2", TTL: 3600},
+		{TypeRecord: dns1cloud.RecordTypeA, HostName: "www", IP: "1.1.1.3", TTL: 3600},
+		{TypeRecord: dns1cloud.RecordTypeAAAA, HostName: "www", IP: "2001:db8::68", TTL: 600},
+		{TypeRecord: dns1cloud.RecordTypeCNAME, HostName: "www.domain.com", MnemonicName: "ftp", TTL: 300},
+		{TypeRecord: dns1cloud.RecordTypeCNAME, HostName: "domain.com", MnemonicName: "blog", TTL: 3600},
+		{TypeRecord: dns1cloud.RecordTypeMX, HostName: "mail.test.com", Priority: "10", TTL: 3600},
+		{TypeRecord: dns1cloud.RecordTypeNS, HostName: "ns1.test.com", ExtHostName: "sub", TTL: 86400},
+		{TypeRecord: dns1cloud.RecordTypeTXT, HostName: "@", Text: `v=spf1 include:_spf.test.com "quoted" \ ~all`, TTL: 3600},
+		{TypeRecord: dns1cloud.RecordTypeTXT, HostName: "long", Text: strings.Repeat("0123456789", 30), TTL: 3600},
+		{
+			TypeRecord: dns1cloud.RecordTypeSRV, HostName: "@", Service: "xmpp-client", Proto: "tcp",
+			Priority: "20", Weight: "0", Port: "5222", Target: "domain-xmpp.test.com", TTL: 21160,
+		},
+		{
+			TypeRecord: dns1cloud.RecordTypeSRV, HostName: "chat", Service: "sip", Proto: "udp",
+			Priority: "10", Weight: "5", Port: "5060", Target: "sip.domain.com", TTL: 3600,
+		},
+		{TypeRecord: dns1cloud.RecordTypeCAA, HostName: "@", Flag: "0", Tag: "issue", Value: "letsencrypt.org", TTL: 3600},
+		{TypeRecord: dns1cloud.RecordTypeTXT, HostName: "bin", Text: "tab\there", TTL: 3600},
+	}, records)
+}
+
+func TestWrite_Errors(t *testing.T) {
+	var buf bytes.Buffer
+
+	assert.EqualError(t, Write(&buf, dns1cloud.Domain{}), "domain name is empty")
+
+	err := Write(&buf, dns1cloud.Domain{
+		Name:          "domain.com",
+		LinkedRecords: []dns1cloud.Record{{ID: 1, TypeRecord: dns1cloud.RecordTypeA, HostName: "@"}},
+	})
+	assert.EqualError(t, err, "could not format record 1: IP is empty")
+}
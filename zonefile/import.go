@@ -0,0 +1,349 @@
+package zonefile
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/reinventer/dns1cloud"
+)
+
+// ParseError is an error in master file
+type ParseError struct {
+	// Line is number of line where the erroneous entry starts
+	Line int
+	Err  error
+}
+
+// Error implements error interface
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Err)
+}
+
+// Parse reads master file and returns its records ready for AddRecord.
+// Names are resolved against origin, which may be empty if the file sets $ORIGIN,
+// then the first $ORIGIN is the name of the domain.
+// SOA records are skipped because they are managed by 1Cloud
+func Parse(r io.Reader, origin string) ([]dns1cloud.Record, error) {
+	entries, err := lex(r)
+	if err != nil {
+		return nil, err
+	}
+
+	p := parser{}
+	if len(origin) > 0 {
+		p.origin = dns1cloud.FQDN("@", origin)
+		p.zone = p.origin
+	}
+
+	var records []dns1cloud.Record
+	for _, e := range entries {
+		record, ok, err := p.parseEntry(e)
+		if err != nil {
+			return nil, &ParseError{Line: e.line, Err: err}
+		}
+		if ok {
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
+
+type parser struct {
+	zone       string
+	origin     string
+	defaultTTL uint32
+	lastOwner  string
+}
+
+func (p *parser) parseEntry(e entry) (dns1cloud.Record, bool, error) {
+	tokens := e.tokens
+
+	if !e.blankOwner && strings.HasPrefix(tokens[0].text, "$") && !tokens[0].quoted {
+		return dns1cloud.Record{}, false, p.parseDirective(tokens)
+	}
+
+	if len(p.origin) == 0 {
+		return dns1cloud.Record{}, false, errors.New("origin is not set")
+	}
+
+	owner := p.lastOwner
+	if !e.blankOwner {
+		owner = p.qualify(tokens[0].text)
+		tokens = tokens[1:]
+	}
+	if len(owner) == 0 {
+		return dns1cloud.Record{}, false, errors.New("owner name is not set")
+	}
+	p.lastOwner = owner
+
+	ttl := p.defaultTTL
+	for len(tokens) > 0 {
+		t := tokens[0].text
+		if strings.EqualFold(t, "IN") {
+			tokens = tokens[1:]
+			continue
+		}
+		if v, ok := parseTTL(t); ok {
+			ttl = v
+			if !dns1cloud.IsValidTTL(ttl) {
+				return dns1cloud.Record{}, false, errors.Errorf("TTL %d is not valid", ttl)
+			}
+			tokens = tokens[1:]
+			continue
+		}
+		if strings.EqualFold(t, "CH") || strings.EqualFold(t, "HS") {
+			return dns1cloud.Record{}, false, errors.Errorf("class %s is not supported", t)
+		}
+		break
+	}
+	if len(tokens) == 0 {
+		return dns1cloud.Record{}, false, errors.New("record type is missing")
+	}
+
+	typeName := tokens[0].text
+	rdata := tokens[1:]
+	if strings.EqualFold(typeName, "SOA") {
+		return dns1cloud.Record{}, false, nil
+	}
+
+	recordType, err := dns1cloud.ParseRecordType(typeName)
+	if err != nil {
+		return dns1cloud.Record{}, false, errors.Errorf("record type %s is not supported", typeName)
+	}
+
+	name, err := p.relative(owner)
+	if err != nil {
+		return dns1cloud.Record{}, false, err
+	}
+
+	record := dns1cloud.Record{TypeRecord: recordType, TTL: ttl}
+	if err = p.parseRData(&record, name, rdata); err != nil {
+		return dns1cloud.Record{}, false, errors.Wrapf(err, "bad %s record", recordType)
+	}
+	return record, true, nil
+}
+
+func (p *parser) parseDirective(tokens []token) error {
+	directive := strings.ToUpper(tokens[0].text)
+	if len(tokens) != 2 {
+		return errors.Errorf("%s directive needs exactly one argument", directive)
+	}
+
+	switch directive {
+	case "$ORIGIN":
+		name := tokens[1].text
+		if !strings.HasSuffix(name, ".") {
+			if len(p.origin) == 0 {
+				return errors.Errorf("origin %q is not absolute", name)
+			}
+			name = name + "." + p.origin
+		}
+		p.origin = name
+		if len(p.zone) == 0 {
+			p.zone = name
+		}
+	case "$TTL":
+		ttl, ok := parseTTL(tokens[1].text)
+		if !ok {
+			return errors.Errorf("bad TTL %q", tokens[1].text)
+		}
+		if !dns1cloud.IsValidTTL(ttl) {
+			return errors.Errorf("TTL %d is not valid", ttl)
+		}
+		p.defaultTTL = ttl
+	default:
+		return errors.Errorf("%s directive is not supported", directive)
+	}
+	return nil
+}
+
+func (p *parser) parseRData(r *dns1cloud.Record, name string, rdata []token) error {
+	switch r.TypeRecord {
+	case dns1cloud.RecordTypeTXT:
+		if len(rdata) == 0 {
+			return errors.New("text is missing")
+		}
+		var sb strings.Builder
+		for _, t := range rdata {
+			sb.WriteString(t.text)
+		}
+		r.HostName = name
+		r.Text = sb.String()
+		return nil
+	case dns1cloud.RecordTypeCAA:
+		if len(rdata) != 3 {
+			return errors.Errorf("expected 3 fields, got %d", len(rdata))
+		}
+		r.HostName = name
+		r.Flag = rdata[0].text
+		r.Tag = rdata[1].text
+		r.Value = rdata[2].text
+		return nil
+	}
+
+	fields := make([]string, len(rdata))
+	for i, t := range rdata {
+		fields[i] = t.text
+	}
+
+	switch r.TypeRecord {
+	case dns1cloud.RecordTypeA, dns1cloud.RecordTypeAAAA:
+		if len(fields) != 1 {
+			return errors.Errorf("expected 1 field, got %d", len(fields))
+		}
+		r.HostName = name
+		r.IP = fields[0]
+	case dns1cloud.RecordTypeCNAME:
+		if len(fields) != 1 {
+			return errors.Errorf("expected 1 field, got %d", len(fields))
+		}
+		r.MnemonicName = name
+		r.HostName = p.target(fields[0])
+	case dns1cloud.RecordTypeMX:
+		if len(fields) != 2 {
+			return errors.Errorf("expected 2 fields, got %d", len(fields))
+		}
+		if name != "@" {
+			return errors.Errorf("only MX records of the domain itself are supported, got %q", name)
+		}
+		if !isDigits(fields[0]) {
+			return errors.Errorf("bad priority %q", fields[0])
+		}
+		r.Priority = fields[0]
+		r.HostName = p.target(fields[1])
+	case dns1cloud.RecordTypeNS:
+		if len(fields) != 1 {
+			return errors.Errorf("expected 1 field, got %d", len(fields))
+		}
+		r.ExtHostName = name
+		r.HostName = p.target(fields[0])
+	case dns1cloud.RecordTypeSRV:
+		if len(fields) != 4 {
+			return errors.Errorf("expected 4 fields, got %d", len(fields))
+		}
+		labels := strings.SplitN(name, ".", 3)
+		if len(labels) < 2 || !strings.HasPrefix(labels[0], "_") || !strings.HasPrefix(labels[1], "_") {
+			return errors.Errorf("name %q is not in form _service._proto.name", name)
+		}
+		for _, f := range fields[:3] {
+			if !isDigits(f) {
+				return errors.Errorf("bad number %q", f)
+			}
+		}
+		r.Service = strings.TrimPrefix(labels[0], "_")
+		r.Proto = strings.TrimPrefix(labels[1], "_")
+		r.HostName = "@"
+		if len(labels) == 3 {
+			r.HostName = labels[2]
+		}
+		r.Priority = fields[0]
+		r.Weight = fields[1]
+		r.Port = fields[2]
+		r.Target = p.target(fields[3])
+	case dns1cloud.RecordTypePTR:
+		if len(fields) != 1 {
+			return errors.Errorf("expected 1 field, got %d", len(fields))
+		}
+		r.HostName = name
+		r.Target = p.target(fields[0])
+	default:
+		return errors.New("record type is not supported")
+	}
+	return nil
+}
+
+// qualify returns absolute name, relative names are resolved against origin
+func (p *parser) qualify(name string) string {
+	switch {
+	case name == "@":
+		return p.origin
+	case strings.HasSuffix(name, "."):
+		return name
+	}
+	return name + "." + p.origin
+}
+
+// relative returns name relative to the domain in the form used by API
+func (p *parser) relative(name string) (string, error) {
+	if strings.EqualFold(name, p.zone) {
+		return "@", nil
+	}
+	suffix := "." + p.zone
+	if len(name) > len(suffix) && strings.EqualFold(name[len(name)-len(suffix):], suffix) {
+		return name[:len(name)-len(suffix)], nil
+	}
+	return "", errors.Errorf("name %q is outside of domain %q", name, p.zone)
+}
+
+// target returns fully qualified target name without trailing dot as API keeps it
+func (p *parser) target(name string) string {
+	if name == "." {
+		return name
+	}
+	return strings.TrimSuffix(p.qualify(name), ".")
+}
+
+// parseTTL parses TTL in seconds or in BIND format like 1h30m
+func parseTTL(s string) (uint32, bool) {
+	if isDigits(s) {
+		v, err := strconv.ParseUint(s, 10, 32)
+		return uint32(v), err == nil
+	}
+
+	var total, cur uint64
+	digits := false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c >= '0' && c <= '9' {
+			cur = cur*10 + uint64(c-'0')
+			digits = true
+			continue
+		}
+		if !digits {
+			return 0, false
+		}
+		switch c {
+		case 's', 'S':
+		case 'm', 'M':
+			cur *= 60
+		case 'h', 'H':
+			cur *= 3600
+		case 'd', 'D':
+			cur *= 86400
+		case 'w', 'W':
+			cur *= 604800
+		default:
+			return 0, false
+		}
+		total += cur
+		cur = 0
+		digits = false
+	}
+	if digits || total > 1<<32-1 {
+		return 0, false
+	}
+	return uint32(total), true
+}
+
+// Adder adds record to domain, it is implemented by dns1cloud.DNS1Cloud
+type Adder interface {
+	AddRecord(ctx context.Context, domainID uint64, record dns1cloud.Record) (dns1cloud.Record, error)
+}
+
+// Import adds records to domain one by one and returns created records.
+// It stops on the first error, records created before it are returned as well
+func Import(ctx context.Context, c Adder, domainID uint64, records []dns1cloud.Record) ([]dns1cloud.Record, error) {
+	created := make([]dns1cloud.Record, 0, len(records))
+	for i, r := range records {
+		res, err := c.AddRecord(ctx, domainID, r)
+		if err != nil {
+			return created, errors.Wrapf(err, "could not add record #%d (%s %s)", i+1, r.TypeRecord, r.HostName)
+		}
+		created = append(created, res)
+	}
+	return created, nil
+}
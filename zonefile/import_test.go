@@ -0,0 +1,181 @@
+package zonefile
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/reinventer/dns1cloud"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	f, err := os.Open(filepath.Join("testdata", "import.zone"))
+	assert.NoError(t, err)
+	defer f.Close()
+
+	records, err := Parse(f, "")
+	assert.NoError(t, err)
+
+	assert.Equal(t, []dns1cloud.Record{
+		{TypeRecord: dns1cloud.RecordTypeNS, ExtHostName: "@", HostName: "ns1.test.com", TTL: 3600},
+		{TypeRecord: dns1cloud.RecordTypeMX, Priority: "10", HostName: "mail.domain.com", TTL: 3600},
+		{TypeRecord: dns1cloud.RecordTypeA, HostName: "@", IP: "1.1.1.2", TTL: 3600},
+		{TypeRecord: dns1cloud.RecordTypeA, HostName: "www", IP: "1.1.1.3", TTL: 300},
+		{TypeRecord: dns1cloud.RecordTypeAAAA, HostName: "www", IP: "2001:db8::68", TTL: 300},
+		{TypeRecord: dns1cloud.RecordTypeCNAME, MnemonicName: "ftp", HostName: "www.domain.com", TTL: 3600},
+		{TypeRecord: dns1cloud.RecordTypeCNAME, MnemonicName: "blog", HostName: "external.test.com", TTL: 3600},
+		{TypeRecord: dns1cloud.RecordTypeTXT, HostName: "@", Text: "v=spf1 include:_spf.test.com ~all", TTL: 3600},
+		{TypeRecord: dns1cloud.RecordTypeTXT, HostName: "long", Text: "first part second part", TTL: 3600},
+		{TypeRecord: dns1cloud.RecordTypeTXT, HostName: "esc", Text: "say \"hi\"\\\t", TTL: 3600},
+		{
+			TypeRecord: dns1cloud.RecordTypeSRV, HostName: "@", Service: "sip", Proto: "tcp",
+			Priority: "10", Weight: "5", Port: "5060", Target: "sip.domain.com", TTL: 86400,
+		},
+		{
+			TypeRecord: dns1cloud.RecordTypeSRV, HostName: "chat", Service: "xmpp-client", Proto: "tcp",
+			Priority: "20", Weight: "0", Port: "5222", Target: "xmpp.test.com", TTL: 3600,
+		},
+		{TypeRecord: dns1cloud.RecordTypeCAA, HostName: "@", Flag: "0", Tag: "issue", Value: "letsencrypt.org", TTL: 3600},
+		{TypeRecord: dns1cloud.RecordTypeNS, ExtHostName: "sub", HostName: "ns2.test.com", TTL: 3600},
+		{TypeRecord: dns1cloud.RecordTypeA, HostName: "host.sub", IP: "1.1.1.4", TTL: 3600},
+	}, records)
+}
+
+func TestParse_Exported(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, Write(&buf, testDomain))
+
+	records, err := Parse(&buf, "")
+	assert.NoError(t, err)
+
+	// every supported record survives export and import
+	assert.Len(t, records, len(testDomain.LinkedRecords)-1)
+	for i, r := range records {
+		exp := testDomain.LinkedRecords[i]
+		assert.Equal(t, exp.TypeRecord, r.TypeRecord)
+		assert.Equal(t, exp.Text, r.Text)
+		assert.Equal(t, exp.IP, r.IP)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	testCases := []struct {
+		name         string
+		zone         string
+		expErrString string
+	}{
+		{
+			name:         "unsupported type",
+			zone:         "$ORIGIN domain.com.\n\n@ IN NAPTR 100 10 \"\" \"\" \"\" .",
+			expErrString: "line 3: record type NAPTR is not supported",
+		},
+		{
+			name:         "invalid TTL",
+			zone:         "$ORIGIN domain.com.\n@ 7 IN A 1.1.1.1",
+			expErrString: "line 2: TTL 7 is not valid",
+		},
+		{
+			name:         "invalid default TTL",
+			zone:         "$TTL 2m",
+			expErrString: "line 1: TTL 120 is not valid",
+		},
+		{
+			name:         "origin is not set",
+			zone:         "www IN A 1.1.1.1",
+			expErrString: "line 1: origin is not set",
+		},
+		{
+			name:         "name outside of origin",
+			zone:         "$ORIGIN domain.com.\nwww.test.com. IN A 1.1.1.1",
+			expErrString: `line 2: name "www.test.com." is outside of domain "domain.com."`,
+		},
+		{
+			name:         "include",
+			zone:         "$INCLUDE other.zone",
+			expErrString: "line 1: $INCLUDE directive is not supported",
+		},
+		{
+			name:         "bad SRV name",
+			zone:         "$ORIGIN domain.com.\nsip IN SRV 10 5 5060 sip",
+			expErrString: `line 2: bad SRV record: name "sip" is not in form _service._proto.name`,
+		},
+		{
+			name:         "MX of subdomain",
+			zone:         "$ORIGIN domain.com.\nsub IN MX 10 mail",
+			expErrString: `line 2: bad MX record: only MX records of the domain itself are supported, got "sub"`,
+		},
+		{
+			name:         "unbalanced parentheses",
+			zone:         "$ORIGIN domain.com.\n@ IN TXT ( \"text\"",
+			expErrString: "line 2: unbalanced parentheses",
+		},
+		{
+			name:         "unterminated string",
+			zone:         "$ORIGIN domain.com.\n@ IN TXT \"text",
+			expErrString: "line 2: unterminated quoted string",
+		},
+		{
+			name:         "missing type",
+			zone:         "$ORIGIN domain.com.\n@ 300 IN",
+			expErrString: "line 2: record type is missing",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			records, err := Parse(strings.NewReader(tc.zone), "")
+			assert.EqualError(t, err, tc.expErrString)
+			assert.Nil(t, records)
+
+			_, ok := err.(*ParseError)
+			assert.True(t, ok)
+		})
+	}
+}
+
+func TestParse_Origin(t *testing.T) {
+	records, err := Parse(strings.NewReader("www IN A 1.1.1.1"), "domain.com")
+	assert.NoError(t, err)
+	assert.Equal(t, []dns1cloud.Record{{TypeRecord: dns1cloud.RecordTypeA, HostName: "www", IP: "1.1.1.1"}}, records)
+}
+
+type adderFunc func(ctx context.Context, domainID uint64, record dns1cloud.Record) (dns1cloud.Record, error)
+
+func (f adderFunc) AddRecord(ctx context.Context, domainID uint64, record dns1cloud.Record) (dns1cloud.Record, error) {
+	return f(ctx, domainID, record)
+}
+
+func TestImport(t *testing.T) {
+	records := []dns1cloud.Record{
+		{TypeRecord: dns1cloud.RecordTypeA, HostName: "@", IP: "1.1.1.1"},
+		{TypeRecord: dns1cloud.RecordTypeA, HostName: "www", IP: "1.1.1.2"},
+		{TypeRecord: dns1cloud.RecordTypeA, HostName: "ftp", IP: "1.1.1.3"},
+	}
+
+	var id uint64
+	c := adderFunc(func(ctx context.Context, domainID uint64, record dns1cloud.Record) (dns1cloud.Record, error) {
+		assert.Equal(t, uint64(123), domainID)
+		if record.HostName == "ftp" {
+			return dns1cloud.Record{}, errors.New("bad response")
+		}
+		id++
+		record.ID = id
+		return record, nil
+	})
+
+	created, err := Import(context.Background(), c, 123, records)
+	assert.EqualError(t, err, "could not add record #3 (A ftp): bad response")
+	assert.Equal(t, []dns1cloud.Record{
+		{ID: 1, TypeRecord: dns1cloud.RecordTypeA, HostName: "@", IP: "1.1.1.1"},
+		{ID: 2, TypeRecord: dns1cloud.RecordTypeA, HostName: "www", IP: "1.1.1.2"},
+	}, created)
+
+	created, err = Import(context.Background(), c, 123, records[:2])
+	assert.NoError(t, err)
+	assert.Len(t, created, 2)
+}
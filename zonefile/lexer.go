@@ -0,0 +1,137 @@
+package zonefile
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// token is a field of master file entry
+type token struct {
+	text   string
+	quoted bool
+}
+
+// entry is a logical line of master file, possibly continued by parentheses
+type entry struct {
+	line       int
+	blankOwner bool
+	tokens     []token
+}
+
+// lex splits master file to entries
+func lex(r io.Reader) ([]entry, error) {
+	var (
+		entries []entry
+		cur     entry
+		depth   int
+		line    int
+	)
+
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line++
+		s := sc.Text()
+
+		if depth == 0 {
+			cur = entry{
+				line:       line,
+				blankOwner: len(s) > 0 && (s[0] == ' ' || s[0] == '\t'),
+			}
+		}
+
+		for i := 0; i < len(s); {
+			c := s[i]
+			switch {
+			case c == ' ' || c == '\t' || c == '\r':
+				i++
+			case c == ';':
+				i = len(s)
+			case c == '(':
+				depth++
+				i++
+			case c == ')':
+				if depth == 0 {
+					return nil, &ParseError{Line: line, Err: errors.New("unbalanced parentheses")}
+				}
+				depth--
+				i++
+			case c == '"':
+				text, n, err := unquote(s[i:])
+				if err != nil {
+					return nil, &ParseError{Line: line, Err: err}
+				}
+				cur.tokens = append(cur.tokens, token{text: text, quoted: true})
+				i += n
+			default:
+				j := i
+				for j < len(s) && !strings.ContainsRune(" \t\r;()\"", rune(s[j])) {
+					if s[j] == '\\' {
+						j++
+					}
+					j++
+				}
+				if j > len(s) {
+					j = len(s)
+				}
+				cur.tokens = append(cur.tokens, token{text: s[i:j]})
+				i = j
+			}
+		}
+
+		if depth == 0 && len(cur.tokens) > 0 {
+			entries = append(entries, cur)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, errors.Wrap(err, "could not read master file")
+	}
+	if depth > 0 {
+		return nil, &ParseError{Line: cur.line, Err: errors.New("unbalanced parentheses")}
+	}
+
+	return entries, nil
+}
+
+// unquote decodes quoted character string at the start of s and returns
+// decoded string and number of consumed bytes
+func unquote(s string) (string, int, error) {
+	var sb strings.Builder
+	for i := 1; i < len(s); i++ {
+		c := s[i]
+		switch c {
+		case '"':
+			return sb.String(), i + 1, nil
+		case '\\':
+			if i+3 < len(s) && isDigits(s[i+1:i+4]) {
+				n, _ := strconv.Atoi(s[i+1 : i+4])
+				if n > 255 {
+					return "", 0, errors.Errorf("bad escape sequence \\%s", s[i+1:i+4])
+				}
+				sb.WriteByte(byte(n))
+				i += 3
+				continue
+			}
+			if i+1 < len(s) {
+				i++
+				sb.WriteByte(s[i])
+				continue
+			}
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return "", 0, errors.New("unterminated quoted string")
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return len(s) > 0
+}
@@ -0,0 +1,34 @@
+package zonefile
+
+import (
+	"strings"
+
+	"github.com/reinventer/dns1cloud"
+)
+
+// target returns target host name qualified against origin.
+// API keeps fully qualified targets without trailing dot, so any name with a dot
+// is treated as absolute
+func target(name, origin string) string {
+	if len(name) > 0 && name != "@" && !strings.HasSuffix(name, ".") && strings.Contains(name, ".") {
+		return dns1cloud.FQDN(name+".", origin)
+	}
+	return dns1cloud.FQDN(name, origin)
+}
+
+// owner returns fully qualified owner name of the record
+func owner(r dns1cloud.Record, origin string) string {
+	switch r.TypeRecord {
+	case dns1cloud.RecordTypeCNAME:
+		return dns1cloud.FQDN(r.MnemonicName, origin)
+	case dns1cloud.RecordTypeMX:
+		return dns1cloud.FQDN("@", origin)
+	case dns1cloud.RecordTypeNS:
+		return dns1cloud.FQDN(r.ExtHostName, origin)
+	case dns1cloud.RecordTypeSRV:
+		service := strings.Trim(r.Service, "._")
+		proto := strings.Trim(r.Proto, "._")
+		return "_" + service + "._" + proto + "." + dns1cloud.FQDN(r.HostName, origin)
+	}
+	return dns1cloud.FQDN(r.HostName, origin)
+}